@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/kstsm/wb-warehouse-control/config"
+	"github.com/kstsm/wb-warehouse-control/database"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/repository"
+	"github.com/kstsm/wb-warehouse-control/internal/service"
+	"github.com/kstsm/wb-warehouse-control/pkg/logger"
+	"github.com/kstsm/wb-warehouse-control/pkg/validator"
+	"golang.org/x/term"
+)
+
+func CreateUser(args []string) error {
+	var req dto.CreateUserRequest
+
+	flags := flag.NewFlagSet("create-user", flag.ContinueOnError)
+	flags.StringVar(&req.UserName, "name", "", "user name")
+	flags.StringVar(&req.Role, "role", "admin", "user role: admin, manager or viewer")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg := config.GetConfig()
+	log := logger.NewSlogLogger()
+
+	plain, err := readPassword()
+	if err != nil {
+		return fmt.Errorf("read password: %w", err)
+	}
+	req.Password = plain
+
+	validate := validator.NewValidator(cfg.Audit.ReasonCodes)
+	if errValidate := validate.Struct(req); errValidate != nil {
+		return errors.New(validate.FormatValidationError(errValidate))
+	}
+
+	conn := database.InitPostgres(ctx, cfg, log)
+	defer conn.Close()
+
+	accounts, err := service.NewAccounts(repository.NewRepository(conn, log), cfg.Auth.BcryptCost)
+	if err != nil {
+		return err
+	}
+
+	user, err := accounts.Create(ctx, req.UserName, req.Password, req.Role, nil)
+	if err != nil {
+		log.Errorf("Error creating user: %v", err)
+		return err
+	}
+
+	log.Infof("User %s created with role %s, id %s", user.Name, user.Role, user.ID)
+
+	return nil
+}
+
+func readPassword() (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", err
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	plain, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
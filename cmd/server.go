@@ -13,6 +13,7 @@ import (
 	"github.com/kstsm/wb-warehouse-control/config"
 	"github.com/kstsm/wb-warehouse-control/database"
 	"github.com/kstsm/wb-warehouse-control/internal/handler"
+	"github.com/kstsm/wb-warehouse-control/internal/middleware"
 	"github.com/kstsm/wb-warehouse-control/internal/repository"
 	"github.com/kstsm/wb-warehouse-control/internal/service"
 	"github.com/kstsm/wb-warehouse-control/pkg/jwt"
@@ -31,18 +32,47 @@ func Run() error {
 
 	cfg := config.GetConfig()
 	log := logger.NewSlogLogger()
+	log.AddProcessor(middleware.RequestIDLogProcessor())
 
 	conn := database.InitPostgres(ctx, cfg, log)
 	defer conn.Close()
 
-	validate := validator.NewValidator()
+	validate := validator.NewValidator(cfg.Audit.ReasonCodes)
 
-	tokenManager := jwt.NewJWTManager(cfg.JWT.Secret, cfg.JWT.TTL, cfg.JWT.Issuer)
+	keys := jwt.NewHMACKeySet(cfg.JWT.Secret)
+	if cfg.JWT.SigningKeyFile != "" {
+		loaded, err := jwt.LoadKeySet(cfg.JWT.SigningKeyFile, cfg.JWT.VerificationKeyFiles)
+		if err != nil {
+			log.Errorf("Error loading JWT keys: %v", err)
+			return err
+		}
+		keys = loaded
+	}
+
+	tokenManager := jwt.NewJWTManager(keys, cfg.JWT.TTL, cfg.JWT.Issuer)
 
 	repo := repository.NewRepository(conn, log)
-	svc := service.NewService(repo, log, tokenManager)
+	historyBroker := service.NewHistoryBroker(repo, log)
+	go historyBroker.Run(ctx)
+
+	accounts, err := service.NewAccounts(repo, cfg.Auth.BcryptCost)
+	if err != nil {
+		log.Errorf("Error initializing accounts: %v", err)
+		return err
+	}
+
+	svc := service.NewService(repo, log, tokenManager, historyBroker, accounts, cfg.Auth)
 	router := handler.NewHandler(svc, log, validate, tokenManager)
 
+	dispatcher := service.NewWebhookDispatcher(repo, log, cfg.Webhook)
+	go dispatcher.Run(ctx)
+
+	tokenCleaner := service.NewTokenCleaner(repo, log)
+	go tokenCleaner.Run(ctx)
+
+	webhookCleaner := service.NewWebhookCleaner(repo, log, cfg.Webhook.Retention)
+	go webhookCleaner.Run(ctx)
+
 	srv := &http.Server{
 		Addr:              fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
 		Handler:           router.NewRouter(),
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/config"
+	"github.com/kstsm/wb-warehouse-control/database"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository"
+	"github.com/kstsm/wb-warehouse-control/internal/service"
+	"github.com/kstsm/wb-warehouse-control/pkg/logger"
+)
+
+func VerifyHistory(args []string) error {
+	var headSeq int64
+	var headHash string
+
+	flags := flag.NewFlagSet("verify-history", flag.ContinueOnError)
+	flags.Int64Var(&headSeq, "head-seq", 0, "expected chain_seq of the head from a previous verification")
+	flags.StringVar(&headHash, "head-hash", "", "expected hash of the head from a previous verification")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var expected *models.HistoryChainAnchor
+	if headSeq != 0 || headHash != "" {
+		if headSeq < 1 || headHash == "" {
+			return errors.New("-head-seq and -head-hash must be set together")
+		}
+		expected = &models.HistoryChainAnchor{ChainSeq: headSeq, Hash: strings.ToLower(headHash)}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg := config.GetConfig()
+	log := logger.NewSlogLogger()
+
+	conn := database.InitPostgres(ctx, cfg, log)
+	defer conn.Close()
+
+	verifier := service.NewHistoryVerifier(repository.NewRepository(conn, log))
+	result, err := verifier.Verify(ctx, expected)
+	if err != nil {
+		log.Errorf("Error verifying history chain: %v", err)
+		return err
+	}
+
+	if result.Broken != nil && result.Broken.HistoryID == uuid.Nil {
+		log.Errorf(
+			"History chain ends before the expected entry chain_seq=%d: %s, %d entries verified",
+			result.Broken.ChainSeq,
+			result.Broken.Reason,
+			result.Verified,
+		)
+		return apperrors.ErrHistoryTampered
+	}
+
+	if result.Broken != nil {
+		log.Errorf(
+			"History chain broken at %s (chain_seq=%d, changed_at=%s): %s, %d entries verified before the break",
+			result.Broken.HistoryID,
+			result.Broken.ChainSeq,
+			result.Broken.ChangedAt.UTC().Format(time.RFC3339Nano),
+			result.Broken.Reason,
+			result.Verified,
+		)
+		return apperrors.ErrHistoryTampered
+	}
+
+	if result.HeadSeq == nil {
+		log.Infof("History chain verified: no entries")
+		return nil
+	}
+	log.Infof(
+		"History chain verified: %d entries, head chain_seq %d, head hash %s",
+		result.Verified,
+		*result.HeadSeq,
+		*result.HeadHash,
+	)
+
+	return nil
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gookit/slog"
@@ -12,6 +13,9 @@ type Config struct {
 	Server   Server
 	Postgres Postgres
 	JWT      JWT
+	Webhook  Webhook
+	Audit    Audit
+	Auth     Auth
 }
 
 type Server struct {
@@ -29,14 +33,48 @@ type Postgres struct {
 }
 
 type JWT struct {
-	Secret string
-	TTL    time.Duration
-	Issuer string
+	Secret               string
+	TTL                  time.Duration
+	Issuer               string
+	SigningKeyFile       string
+	VerificationKeyFiles []string
+}
+
+type Webhook struct {
+	DispatchInterval time.Duration
+	Timeout          time.Duration
+	MaxAttempts      int
+	BackoffBase      time.Duration
+	BackoffMax       time.Duration
+	BatchSize        int
+	Retention        time.Duration
+}
+
+type Audit struct {
+	ReasonCodes []string
+}
+
+type Auth struct {
+	SelfRegistration bool
+	BcryptCost       int
+	RefreshTTL       time.Duration
 }
 
 func GetConfig() Config {
 	viper.SetConfigFile(".env")
 
+	viper.SetDefault("WEBHOOK_DISPATCH_INTERVAL", "5s")
+	viper.SetDefault("WEBHOOK_TIMEOUT", "10s")
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 8)
+	viper.SetDefault("WEBHOOK_BACKOFF_BASE", "30s")
+	viper.SetDefault("WEBHOOK_BACKOFF_MAX", "1h")
+	viper.SetDefault("WEBHOOK_BATCH_SIZE", 50)
+	viper.SetDefault("WEBHOOK_RETENTION", "720h")
+	viper.SetDefault("AUTH_SELF_REGISTRATION", false)
+	viper.SetDefault("AUTH_BCRYPT_COST", 10)
+	viper.SetDefault("AUTH_REFRESH_TTL", "720h")
+	viper.SetDefault("CHANGE_REASON_CODES", "correction,price_change,supplier_update,data_entry_error,inventory,other")
+
 	err := viper.ReadInConfig()
 	if err != nil {
 		slog.Fatal("Failed to read .env file", "error", err)
@@ -57,9 +95,39 @@ func GetConfig() Config {
 			Ssl:      viper.GetString("POSTGRES_SSL"),
 		},
 		JWT: JWT{
-			Secret: viper.GetString("JWT_SECRET"),
-			TTL:    viper.GetDuration("JWT_TTL"),
-			Issuer: viper.GetString("JWT_ISSUER"),
+			Secret:               viper.GetString("JWT_SECRET"),
+			TTL:                  viper.GetDuration("JWT_TTL"),
+			Issuer:               viper.GetString("JWT_ISSUER"),
+			SigningKeyFile:       viper.GetString("JWT_SIGNING_KEY_FILE"),
+			VerificationKeyFiles: splitList(viper.GetString("JWT_VERIFICATION_KEY_FILES")),
+		},
+		Webhook: Webhook{
+			DispatchInterval: viper.GetDuration("WEBHOOK_DISPATCH_INTERVAL"),
+			Timeout:          viper.GetDuration("WEBHOOK_TIMEOUT"),
+			MaxAttempts:      viper.GetInt("WEBHOOK_MAX_ATTEMPTS"),
+			BackoffBase:      viper.GetDuration("WEBHOOK_BACKOFF_BASE"),
+			BackoffMax:       viper.GetDuration("WEBHOOK_BACKOFF_MAX"),
+			BatchSize:        viper.GetInt("WEBHOOK_BATCH_SIZE"),
+			Retention:        viper.GetDuration("WEBHOOK_RETENTION"),
+		},
+		Audit: Audit{
+			ReasonCodes: splitList(viper.GetString("CHANGE_REASON_CODES")),
+		},
+		Auth: Auth{
+			SelfRegistration: viper.GetBool("AUTH_SELF_REGISTRATION"),
+			BcryptCost:       viper.GetInt("AUTH_BCRYPT_COST"),
+			RefreshTTL:       viper.GetDuration("AUTH_REFRESH_TTL"),
 		},
 	}
 }
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -3,9 +3,39 @@ package apperrors
 import "errors"
 
 var (
-	ErrItemNotFound      = errors.New("item not found")
-	ErrEmptyDate         = errors.New("empty date string")
-	ErrUserNotFound      = errors.New("user not found")
-	ErrUserAlreadyExists = errors.New("user already exists")
-	ErrRoleMismatch      = errors.New("user role mismatch")
+	ErrItemNotFound         = errors.New("item not found")
+	ErrEmptyDate            = errors.New("empty date string")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrUserAlreadyExists    = errors.New("user already exists")
+	ErrInvalidCredentials   = errors.New("invalid user name or password")
+	ErrRegistrationDisabled = errors.New("self-registration is disabled")
+	ErrPasswordTooLong      = errors.New("password is too long")
+	ErrUserDisabled         = errors.New("user is disabled")
+	ErrSelfLockout          = errors.New("administrators cannot demote, disable or delete themselves")
+	ErrLastAdmin            = errors.New("cannot demote, disable or delete the last active administrator")
+	ErrInvalidRefreshToken  = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused   = errors.New("refresh token reused")
+	ErrAPIKeyNotFound       = errors.New("api key not found")
+	ErrInvalidAPIKey        = errors.New("invalid, expired or revoked api key")
+	ErrAPIKeyExpiresInPast  = errors.New("api key expiry must be in the future")
+	ErrVersionConflict      = errors.New("item version conflict")
+	ErrInsufficientStock    = errors.New("insufficient stock")
+	ErrWarehouseNotFound    = errors.New("warehouse not found")
+	ErrWarehouseExists      = errors.New("warehouse already exists")
+	ErrLocationNotFound     = errors.New("storage location not found")
+	ErrLocationExists       = errors.New("storage location already exists")
+	ErrLocationMismatch     = errors.New("storage locations belong to different warehouses")
+	ErrSKUExists            = errors.New("sku already exists")
+	ErrBarcodeExists        = errors.New("barcode already assigned to another item")
+	ErrCategoryNotFound     = errors.New("category not found")
+	ErrParentNotFound       = errors.New("parent category not found")
+	ErrCategoryExists       = errors.New("category already exists")
+	ErrCategoryCycle        = errors.New("category cannot be moved into its own subtree")
+	ErrCategoryInUse        = errors.New("category has items or subcategories")
+	ErrAlertNotFound        = errors.New("alert not found")
+	ErrAlertAcknowledged    = errors.New("alert already acknowledged")
+	ErrWebhookNotFound      = errors.New("webhook subscription not found")
+	ErrHistoryNotFound      = errors.New("history entry not found")
+	ErrNotRevertible        = errors.New("history entry has no item snapshot to revert to")
+	ErrHistoryTampered      = errors.New("history hash chain is broken")
 )
@@ -0,0 +1,46 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func AlertToResponse(alert *models.Alert) dto.AlertResponse {
+	var acknowledgedAt *string
+	if alert.AcknowledgedAt != nil {
+		formatted := alert.AcknowledgedAt.UTC().Format(time.RFC3339)
+		acknowledgedAt = &formatted
+	}
+
+	var acknowledgedBy *string
+	if alert.AcknowledgedBy != nil {
+		id := alert.AcknowledgedBy.String()
+		acknowledgedBy = &id
+	}
+
+	return dto.AlertResponse{
+		ID:                   alert.ID.String(),
+		ItemID:               alert.ItemID.String(),
+		ItemSKU:              alert.ItemSKU,
+		ItemName:             alert.ItemName,
+		Kind:                 alert.Kind,
+		Quantity:             alert.Quantity,
+		ReorderPoint:         alert.ReorderPoint,
+		CreatedAt:            alert.CreatedAt.UTC().Format(time.RFC3339),
+		AcknowledgedAt:       acknowledgedAt,
+		AcknowledgedBy:       acknowledgedBy,
+		APIKeyID:             formatOptionalUUID(alert.APIKeyID),
+		AcknowledgedAPIKeyID: formatOptionalUUID(alert.AcknowledgedAPIKeyID),
+	}
+}
+
+func AlertsToResponse(alerts []*models.Alert) []dto.AlertResponse {
+	res := make([]dto.AlertResponse, len(alerts))
+	for i, a := range alerts {
+		res[i] = AlertToResponse(a)
+	}
+
+	return res
+}
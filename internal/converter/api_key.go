@@ -0,0 +1,57 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func APIKeyToResponse(key *models.APIKey) dto.APIKeyResponse {
+	var createdBy *string
+	if key.CreatedBy != nil {
+		id := key.CreatedBy.String()
+		createdBy = &id
+	}
+
+	return dto.APIKeyResponse{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Role:       key.Role,
+		KeyPrefix:  key.KeyPrefix,
+		ExpiresAt:  formatOptionalTime(key.ExpiresAt),
+		LastUsedAt: formatOptionalTime(key.LastUsedAt),
+		CreatedBy:  createdBy,
+		CreatedAt:  key.CreatedAt.UTC().Format(time.RFC3339),
+		Revoked:    key.RevokedAt != nil,
+		RevokedAt:  formatOptionalTime(key.RevokedAt),
+	}
+}
+
+func APIKeysToResponse(keys []*models.APIKey) []dto.APIKeyResponse {
+	res := make([]dto.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		res[i] = APIKeyToResponse(key)
+	}
+
+	return res
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
+
+func formatOptionalUUID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	formatted := id.String()
+	return &formatted
+}
@@ -0,0 +1,34 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func CategoryToResponse(category *models.Category) dto.CategoryResponse {
+	var parentID *string
+	if category.ParentID != nil {
+		id := category.ParentID.String()
+		parentID = &id
+	}
+
+	return dto.CategoryResponse{
+		ID:        category.ID.String(),
+		ParentID:  parentID,
+		Name:      category.Name,
+		Path:      category.Path,
+		CreatedAt: category.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: category.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func CategoriesToResponse(categories []*models.Category) []dto.CategoryResponse {
+	res := make([]dto.CategoryResponse, len(categories))
+	for i, c := range categories {
+		res[i] = CategoryToResponse(c)
+	}
+
+	return res
+}
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"reflect"
 	"sort"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/kstsm/wb-warehouse-control/internal/dto"
 	"github.com/kstsm/wb-warehouse-control/internal/models"
 )
@@ -17,14 +19,47 @@ func HistoryToResponse(history *models.History) dto.HistoryResponse {
 		userID = &id
 	}
 
+	var warehouseID *string
+	if history.WarehouseID != nil {
+		id := history.WarehouseID.String()
+		warehouseID = &id
+	}
+
+	var locationID *string
+	if history.LocationID != nil {
+		id := history.LocationID.String()
+		locationID = &id
+	}
+
+	var revertedFrom *string
+	if history.RevertedFrom != nil {
+		id := history.RevertedFrom.String()
+		revertedFrom = &id
+	}
+
+	var apiKeyID *string
+	if history.APIKeyID != nil {
+		id := history.APIKeyID.String()
+		apiKeyID = &id
+	}
+
 	return dto.HistoryResponse{
-		ID:        history.ID.String(),
-		ItemID:    history.ItemID.String(),
-		Action:    history.Action,
-		UserID:    userID,
-		ChangedAt: history.ChangedAt.UTC().Format(time.RFC3339),
-		OldData:   history.OldData,
-		NewData:   history.NewData,
+		ID:            history.ID.String(),
+		ItemID:        history.ItemID.String(),
+		Action:        history.Action,
+		UserID:        userID,
+		ChangedAt:     history.ChangedAt.UTC().Format(time.RFC3339),
+		OldData:       history.OldData,
+		NewData:       history.NewData,
+		QuantityDelta: history.QuantityDelta,
+		ReasonCode:    history.ReasonCode,
+		Reference:     history.Reference,
+		WarehouseID:   warehouseID,
+		LocationID:    locationID,
+		RevertedFrom:  revertedFrom,
+		Reason:        history.Reason,
+		RequestID:     history.RequestID,
+		APIKeyID:      apiKeyID,
 	}
 }
 
@@ -56,6 +91,10 @@ func HistoriesToResponseWithDiff(histories []*models.History) []dto.HistoryWithD
 }
 
 func calculateDiff(oldData, newData map[string]any) []dto.DiffResponse {
+	return diffMaps("", oldData, newData)
+}
+
+func diffMaps(prefix string, oldData, newData map[string]any) []dto.DiffResponse {
 	var diff []dto.DiffResponse
 
 	allKeys := make(map[string]bool)
@@ -75,23 +114,29 @@ func calculateDiff(oldData, newData map[string]any) []dto.DiffResponse {
 	for _, key := range sortedKeys {
 		oldVal, oldExists := oldData[key]
 		newVal, newExists := newData[key]
+		field := prefix + key
+
+		if oldMap, newMap, ok := nestedMaps(oldVal, newVal); ok {
+			diff = append(diff, diffMaps(field+".", oldMap, newMap)...)
+			continue
+		}
 
 		switch {
 		case !oldExists:
 			diff = append(diff, dto.DiffResponse{
-				Field:    key,
+				Field:    field,
 				OldValue: nil,
 				NewValue: newVal,
 			})
 		case !newExists:
 			diff = append(diff, dto.DiffResponse{
-				Field:    key,
+				Field:    field,
 				OldValue: oldVal,
 				NewValue: nil,
 			})
 		case !reflect.DeepEqual(oldVal, newVal):
 			diff = append(diff, dto.DiffResponse{
-				Field:    key,
+				Field:    field,
 				OldValue: oldVal,
 				NewValue: newVal,
 			})
@@ -101,6 +146,22 @@ func calculateDiff(oldData, newData map[string]any) []dto.DiffResponse {
 	return diff
 }
 
+func nestedMaps(oldVal, newVal any) (map[string]any, map[string]any, bool) {
+	oldMap, oldIsMap := oldVal.(map[string]any)
+	newMap, newIsMap := newVal.(map[string]any)
+
+	switch {
+	case oldIsMap && newIsMap:
+		return oldMap, newMap, true
+	case oldIsMap && newVal == nil:
+		return oldMap, nil, true
+	case newIsMap && oldVal == nil:
+		return nil, newMap, true
+	}
+
+	return nil, nil, false
+}
+
 func HistoryToExportResponse(history *models.History) dto.HistoryExportResponse {
 	var userID string
 	if history.UserID != nil {
@@ -123,14 +184,68 @@ func HistoryToExportResponse(history *models.History) dto.HistoryExportResponse
 		}
 	}
 
+	var quantityDelta string
+	if history.QuantityDelta != nil {
+		quantityDelta = strconv.Itoa(*history.QuantityDelta)
+	}
+
+	var reasonCode string
+	if history.ReasonCode != nil {
+		reasonCode = *history.ReasonCode
+	}
+
+	var reference string
+	if history.Reference != nil {
+		reference = *history.Reference
+	}
+
+	var warehouseID string
+	if history.WarehouseID != nil {
+		warehouseID = history.WarehouseID.String()
+	}
+
+	var locationID string
+	if history.LocationID != nil {
+		locationID = history.LocationID.String()
+	}
+
+	var revertedFrom string
+	if history.RevertedFrom != nil {
+		revertedFrom = history.RevertedFrom.String()
+	}
+
+	var reason string
+	if history.Reason != nil {
+		reason = *history.Reason
+	}
+
+	var requestID string
+	if history.RequestID != nil {
+		requestID = *history.RequestID
+	}
+
+	var apiKeyID string
+	if history.APIKeyID != nil {
+		apiKeyID = history.APIKeyID.String()
+	}
+
 	return dto.HistoryExportResponse{
-		ID:        history.ID.String(),
-		ItemID:    history.ItemID.String(),
-		Action:    history.Action,
-		UserID:    userID,
-		ChangedAt: history.ChangedAt.UTC().Format(time.RFC3339),
-		OldData:   oldDataStr,
-		NewData:   newDataStr,
+		ID:            history.ID.String(),
+		ItemID:        history.ItemID.String(),
+		Action:        history.Action,
+		UserID:        userID,
+		ChangedAt:     history.ChangedAt.UTC().Format(time.RFC3339),
+		OldData:       oldDataStr,
+		NewData:       newDataStr,
+		QuantityDelta: quantityDelta,
+		ReasonCode:    reasonCode,
+		Reference:     reference,
+		WarehouseID:   warehouseID,
+		LocationID:    locationID,
+		RevertedFrom:  revertedFrom,
+		Reason:        reason,
+		RequestID:     requestID,
+		APIKeyID:      apiKeyID,
 	}
 }
 
@@ -142,3 +257,25 @@ func HistoriesToExportResponse(histories []*models.History) []dto.HistoryExportR
 
 	return res
 }
+
+func HistoryVerificationToResponse(result *models.HistoryVerification) dto.HistoryVerifyResponse {
+	resp := dto.HistoryVerifyResponse{
+		Valid:    result.Broken == nil,
+		Verified: result.Verified,
+		HeadSeq:  result.HeadSeq,
+		HeadHash: result.HeadHash,
+	}
+
+	if result.Broken != nil {
+		resp.Broken = &dto.HistoryChainBreakResponse{
+			ChainSeq: result.Broken.ChainSeq,
+			Reason:   result.Broken.Reason,
+		}
+		if result.Broken.HistoryID != uuid.Nil {
+			resp.Broken.HistoryID = result.Broken.HistoryID.String()
+			resp.Broken.ChangedAt = result.Broken.ChangedAt.UTC().Format(time.RFC3339)
+		}
+	}
+
+	return resp
+}
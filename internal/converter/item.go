@@ -24,14 +24,45 @@ func formatRublesAmount(amount int) string {
 }
 
 func ItemToResponse(item *models.Item) dto.ItemResponse {
+	var deletedAt *string
+	if item.DeletedAt != nil {
+		formatted := item.DeletedAt.UTC().Format(time.RFC3339)
+		deletedAt = &formatted
+	}
+
+	var categoryID *string
+	if item.CategoryID != nil {
+		id := item.CategoryID.String()
+		categoryID = &id
+	}
+
 	return dto.ItemResponse{
-		ID:          item.ID.String(),
-		Name:        item.Name,
-		Description: item.Description,
-		Quantity:    item.Quantity,
-		Price:       formatRublesAmount(item.Price),
-		CreatedAt:   item.CreatedAt.UTC().Format(time.RFC3339),
-		UpdatedAt:   item.UpdatedAt.UTC().Format(time.RFC3339),
+		ID:           item.ID.String(),
+		SKU:          item.SKU,
+		Barcodes:     item.Barcodes,
+		CategoryID:   categoryID,
+		Name:         item.Name,
+		Description:  item.Description,
+		Attributes:   item.Attributes,
+		Tags:         item.Tags,
+		ReorderPoint: item.ReorderPoint,
+		Quantity:     item.Quantity,
+		Price:        formatRublesAmount(item.Price),
+		Version:      item.Version,
+		CreatedAt:    item.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:    item.UpdatedAt.UTC().Format(time.RFC3339),
+		DeletedAt:    deletedAt,
+		Stocks:       ItemStocksToResponse(item.Stocks),
+		Locations:    ItemLocationsToResponse(item.Locations),
+	}
+}
+
+func ItemToStockAdjustmentResponse(item *models.Item, delta int) dto.StockAdjustmentResponse {
+	return dto.StockAdjustmentResponse{
+		ItemID:   item.ID.String(),
+		Quantity: item.Quantity,
+		Delta:    delta,
+		Version:  item.Version,
 	}
 }
 
@@ -0,0 +1,61 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func LocationToResponse(location *models.StorageLocation) dto.LocationResponse {
+	return dto.LocationResponse{
+		ID:          location.ID.String(),
+		WarehouseID: location.WarehouseID.String(),
+		Zone:        location.Zone,
+		Aisle:       location.Aisle,
+		Rack:        location.Rack,
+		Bin:         location.Bin,
+		Code:        location.Code,
+		CreatedAt:   location.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func LocationsToResponse(locations []*models.StorageLocation) []dto.LocationResponse {
+	res := make([]dto.LocationResponse, len(locations))
+	for i, l := range locations {
+		res[i] = LocationToResponse(l)
+	}
+
+	return res
+}
+
+func ItemLocationsToResponse(locations []models.ItemLocation) []dto.ItemLocationResponse {
+	if locations == nil {
+		return nil
+	}
+
+	res := make([]dto.ItemLocationResponse, len(locations))
+	for i, l := range locations {
+		res[i] = dto.ItemLocationResponse{
+			LocationID:   l.LocationID.String(),
+			LocationCode: l.LocationCode,
+			WarehouseID:  l.WarehouseID.String(),
+			Quantity:     l.Quantity,
+		}
+	}
+
+	return res
+}
+
+func LocationContentsToResponse(contents []*models.LocationContent) []dto.LocationContentResponse {
+	res := make([]dto.LocationContentResponse, len(contents))
+	for i, c := range contents {
+		res[i] = dto.LocationContentResponse{
+			ItemID:   c.ItemID.String(),
+			Name:     c.Name,
+			Quantity: c.Quantity,
+		}
+	}
+
+	return res
+}
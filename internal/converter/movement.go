@@ -0,0 +1,57 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func MovementToResponse(movement *models.StockMovement) dto.MovementResponse {
+	var userID *string
+	if movement.UserID != nil {
+		id := movement.UserID.String()
+		userID = &id
+	}
+
+	var warehouseID *string
+	if movement.WarehouseID != nil {
+		id := movement.WarehouseID.String()
+		warehouseID = &id
+	}
+
+	return dto.MovementResponse{
+		ID:          movement.ID.String(),
+		ItemID:      movement.ItemID.String(),
+		WarehouseID: warehouseID,
+		Kind:        movement.Kind,
+		Quantity:    movement.Quantity,
+		Reference:   movement.Reference,
+		UserID:      userID,
+		CreatedAt:   movement.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func MovementsToResponse(movements []*models.StockMovement) []dto.MovementResponse {
+	res := make([]dto.MovementResponse, len(movements))
+	for i, m := range movements {
+		res[i] = MovementToResponse(m)
+	}
+
+	return res
+}
+
+func DiscrepanciesToResponse(discrepancies []*models.StockDiscrepancy) []dto.StockDiscrepancyResponse {
+	res := make([]dto.StockDiscrepancyResponse, len(discrepancies))
+	for i, d := range discrepancies {
+		res[i] = dto.StockDiscrepancyResponse{
+			ItemID:         d.ItemID.String(),
+			Name:           d.Name,
+			Quantity:       d.Quantity,
+			LedgerQuantity: d.LedgerQuantity,
+			Difference:     d.Quantity - d.LedgerQuantity,
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,77 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func UserToResponse(user *models.User) dto.UserResponse {
+	var disabledAt *string
+	if user.DisabledAt != nil {
+		at := user.DisabledAt.UTC().Format(time.RFC3339)
+		disabledAt = &at
+	}
+
+	return dto.UserResponse{
+		ID:         user.ID.String(),
+		UserName:   user.Name,
+		Role:       user.Role,
+		Disabled:   user.DisabledAt != nil,
+		DisabledAt: disabledAt,
+		CreatedAt:  user.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:  user.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func UsersToResponse(users []*models.User) []dto.UserResponse {
+	res := make([]dto.UserResponse, len(users))
+	for i, user := range users {
+		res[i] = UserToResponse(user)
+	}
+
+	return res
+}
+
+func UserHistoryToResponse(history []*models.UserHistory) []dto.UserHistoryResponse {
+	res := make([]dto.UserHistoryResponse, len(history))
+	for i, entry := range history {
+		var changedBy *string
+		if entry.ChangedBy != nil {
+			id := entry.ChangedBy.String()
+			changedBy = &id
+		}
+
+		var apiKeyID *string
+		if entry.APIKeyID != nil {
+			id := entry.APIKeyID.String()
+			apiKeyID = &id
+		}
+
+		res[i] = dto.UserHistoryResponse{
+			ID:         entry.ID.String(),
+			UserID:     entry.UserID.String(),
+			Action:     entry.Action,
+			ChangedBy:  changedBy,
+			ChangedAt:  entry.ChangedAt.UTC().Format(time.RFC3339),
+			OldData:    entry.OldData,
+			NewData:    entry.NewData,
+			ReasonCode: entry.ReasonCode,
+			Reason:     entry.Reason,
+			RequestID:  entry.RequestID,
+			APIKeyID:   apiKeyID,
+		}
+	}
+
+	return res
+}
+
+func AuthTokensToResponse(tokens *models.AuthTokens) dto.LoginResponse {
+	return dto.LoginResponse{
+		Token:        tokens.AccessToken,
+		ExpiresIn:    int(tokens.ExpiresIn.Seconds()),
+		RefreshToken: tokens.RefreshToken,
+		Role:         tokens.Role,
+	}
+}
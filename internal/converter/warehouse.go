@@ -0,0 +1,57 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func WarehouseToResponse(warehouse *models.Warehouse) dto.WarehouseResponse {
+	return dto.WarehouseResponse{
+		ID:        warehouse.ID.String(),
+		Code:      warehouse.Code,
+		Name:      warehouse.Name,
+		Address:   warehouse.Address,
+		IsDefault: warehouse.IsDefault,
+		CreatedAt: warehouse.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: warehouse.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func WarehousesToResponse(warehouses []*models.Warehouse) []dto.WarehouseResponse {
+	res := make([]dto.WarehouseResponse, len(warehouses))
+	for i, w := range warehouses {
+		res[i] = WarehouseToResponse(w)
+	}
+
+	return res
+}
+
+func ItemStocksToResponse(stocks []models.ItemStock) []dto.ItemStockResponse {
+	if stocks == nil {
+		return nil
+	}
+
+	res := make([]dto.ItemStockResponse, len(stocks))
+	for i, s := range stocks {
+		res[i] = dto.ItemStockResponse{
+			WarehouseID:   s.WarehouseID.String(),
+			WarehouseCode: s.WarehouseCode,
+			WarehouseName: s.WarehouseName,
+			Quantity:      s.Quantity,
+		}
+	}
+
+	return res
+}
+
+func TransferToResponse(transfer *models.Transfer) dto.TransferResponse {
+	return dto.TransferResponse{
+		ItemID:          transfer.ItemID.String(),
+		FromWarehouseID: transfer.FromWarehouseID.String(),
+		ToWarehouseID:   transfer.ToWarehouseID.String(),
+		Quantity:        transfer.Quantity,
+		Stocks:          ItemStocksToResponse(transfer.Stocks),
+	}
+}
@@ -0,0 +1,84 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func WebhookToResponse(subscription *models.WebhookSubscription) dto.WebhookResponse {
+	return dto.WebhookResponse{
+		ID:        subscription.ID.String(),
+		URL:       subscription.URL,
+		Events:    subscription.Events,
+		IsActive:  subscription.IsActive,
+		CreatedAt: subscription.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: subscription.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func WebhooksToResponse(subscriptions []*models.WebhookSubscription) []dto.WebhookResponse {
+	res := make([]dto.WebhookResponse, len(subscriptions))
+	for i, s := range subscriptions {
+		res[i] = WebhookToResponse(s)
+	}
+
+	return res
+}
+
+func WebhookDeliveryToResponse(delivery *models.WebhookDelivery) dto.WebhookDeliveryResponse {
+	var nextAttemptAt *string
+	if delivery.Status == models.WebhookDeliveryPending {
+		formatted := delivery.NextAttemptAt.UTC().Format(time.RFC3339)
+		nextAttemptAt = &formatted
+	}
+
+	var deliveredAt *string
+	if delivery.DeliveredAt != nil {
+		formatted := delivery.DeliveredAt.UTC().Format(time.RFC3339)
+		deliveredAt = &formatted
+	}
+
+	return dto.WebhookDeliveryResponse{
+		ID:             delivery.ID.String(),
+		SubscriptionID: delivery.SubscriptionID.String(),
+		EventID:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		ItemID:         delivery.ItemID.String(),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  nextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.UTC().Format(time.RFC3339),
+		DeliveredAt:    deliveredAt,
+	}
+}
+
+func WebhookDeliveriesToResponse(deliveries []*models.WebhookDelivery) []dto.WebhookDeliveryResponse {
+	res := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		res[i] = WebhookDeliveryToResponse(d)
+	}
+
+	return res
+}
+
+func PendingWebhookToPayload(delivery *models.PendingWebhookDelivery) dto.WebhookEventPayload {
+	var userID *string
+	if delivery.UserID != nil {
+		id := delivery.UserID.String()
+		userID = &id
+	}
+
+	return dto.WebhookEventPayload{
+		ID:         delivery.EventID.String(),
+		Type:       delivery.EventType,
+		OccurredAt: delivery.OccurredAt.UTC().Format(time.RFC3339),
+		ItemID:     delivery.ItemID.String(),
+		UserID:     userID,
+		APIKeyID:   formatOptionalUUID(delivery.APIKeyID),
+		Data:       delivery.Payload,
+	}
+}
@@ -2,33 +2,245 @@ package dto
 
 import (
 	"time"
+
+	"github.com/kstsm/wb-warehouse-control/pkg/cursor"
 )
 
+type ChangeReason struct {
+	Reason     *string `json:"reason"      validate:"omitempty,max=500"`
+	ReasonCode *string `json:"reason_code" validate:"omitempty,reason_code"`
+}
+
 type CreateItemRequest struct {
-	Name        string `json:"name"        validate:"required,min=1"`
-	Description string `json:"description"`
-	Quantity    int    `json:"quantity"    validate:"required,min=1"`
-	Price       int    `json:"price"       validate:"required,min=1"`
+	SKU          string         `json:"sku"          validate:"required,sku"`
+	Barcodes     []string       `json:"barcodes"     validate:"omitempty,max=20,unique,dive,barcode"`
+	CategoryID   *string        `json:"category_id"  validate:"omitempty,uuid"`
+	Name         string         `json:"name"         validate:"required,min=1"`
+	Description  string         `json:"description"`
+	Attributes   map[string]any `json:"attributes"   validate:"omitempty,max=50,dive,keys,attribute_key,endkeys"`
+	Tags         []string       `json:"tags"         validate:"omitempty,max=30,unique,dive,tag"`
+	ReorderPoint *int           `json:"reorder_point" validate:"omitempty,min=0"`
+	Quantity     int            `json:"quantity"     validate:"required,min=1"`
+	Price        int            `json:"price"        validate:"required,min=1"`
+	WarehouseID  *string        `json:"warehouse_id" validate:"omitempty,uuid"`
+
+	ChangeReason
 }
 
 type UpdateItemRequest struct {
-	Name        *string `json:"name"        validate:"omitempty,min=1"`
-	Description *string `json:"description"`
-	Quantity    *int    `json:"quantity"    validate:"omitempty,min=0"`
-	Price       *int    `json:"price"       validate:"omitempty,min=0"`
+	SKU           *string         `json:"sku"          validate:"omitempty,sku"`
+	Barcodes      *[]string       `json:"barcodes"     validate:"omitempty,max=20,unique,dive,barcode"`
+	CategoryID    *string         `json:"category_id"  validate:"omitempty,uuid,excluded_with=ClearCategory"`
+	ClearCategory bool            `json:"clear_category"`
+	Name          *string         `json:"name"         validate:"omitempty,min=1"`
+	Description   *string         `json:"description"`
+	Attributes    *map[string]any `json:"attributes"   validate:"omitempty,max=50,dive,keys,attribute_key,endkeys"`
+	Tags          *[]string       `json:"tags"         validate:"omitempty,max=30,unique,dive,tag"`
+	ReorderPoint  *int            `json:"reorder_point" validate:"omitempty,min=0"`
+	Quantity      *int            `json:"quantity"     validate:"omitempty,min=0"`
+	Price         *int            `json:"price"        validate:"omitempty,min=0"`
+	Version       *int            `json:"version"      validate:"omitempty,min=1"`
+	WarehouseID   *string         `json:"warehouse_id" validate:"omitempty,uuid"`
+
+	ChangeReason
+}
+
+type RevertItemRequest struct {
+	HistoryID   string  `json:"history_id"   validate:"required,uuid"`
+	Version     *int    `json:"version"      validate:"omitempty,min=1"`
+	WarehouseID *string `json:"warehouse_id" validate:"omitempty,uuid"`
+
+	ChangeReason
+}
+
+type AdjustStockRequest struct {
+	Delta       int     `json:"delta"        validate:"required"`
+	Reason      string  `json:"reason"       validate:"required,adjust_reason"`
+	Comment     *string `json:"comment"      validate:"omitempty,max=500"`
+	Reference   *string `json:"reference"    validate:"omitempty,max=128"`
+	WarehouseID *string `json:"warehouse_id" validate:"omitempty,uuid"`
+
+	ChangeReason
+}
+
+type TransferRequest struct {
+	ItemID          string  `json:"item_id"           validate:"required,uuid"`
+	FromWarehouseID string  `json:"from_warehouse_id" validate:"required,uuid"`
+	ToWarehouseID   string  `json:"to_warehouse_id"   validate:"required,uuid,nefield=FromWarehouseID"`
+	Quantity        int     `json:"quantity"          validate:"required,min=1"`
+	Reference       *string `json:"reference"         validate:"omitempty,max=128"`
+
+	ChangeReason
+}
+
+type CreateLocationRequest struct {
+	WarehouseID string `json:"warehouse_id" validate:"required,uuid"`
+	Zone        string `json:"zone"         validate:"required,alphanum,max=16"`
+	Aisle       string `json:"aisle"        validate:"required,alphanum,max=16"`
+	Rack        string `json:"rack"         validate:"required,alphanum,max=16"`
+	Bin         string `json:"bin"          validate:"required,alphanum,max=16"`
+}
+
+type PutawayRequest struct {
+	ItemID     string  `json:"item_id"     validate:"required,uuid"`
+	LocationID string  `json:"location_id" validate:"required,uuid"`
+	Quantity   int     `json:"quantity"    validate:"required,min=1"`
+	Reference  *string `json:"reference"   validate:"omitempty,max=128"`
+
+	ChangeReason
+}
+
+type MoveLocationRequest struct {
+	ItemID         string  `json:"item_id"          validate:"required,uuid"`
+	FromLocationID string  `json:"from_location_id" validate:"required,uuid"`
+	ToLocationID   string  `json:"to_location_id"   validate:"required,uuid,nefield=FromLocationID"`
+	Quantity       int     `json:"quantity"         validate:"required,min=1"`
+	Reference      *string `json:"reference"        validate:"omitempty,max=128"`
+
+	ChangeReason
+}
+
+type CreateCategoryRequest struct {
+	Name     string  `json:"name"      validate:"required,min=1,max=128"`
+	ParentID *string `json:"parent_id" validate:"omitempty,uuid"`
+}
+
+type UpdateCategoryRequest struct {
+	Name       *string `json:"name"         validate:"omitempty,min=1,max=128"`
+	ParentID   *string `json:"parent_id"    validate:"omitempty,uuid,excluded_with=MoveToRoot"`
+	MoveToRoot bool    `json:"move_to_root"`
+}
+
+type CreateWarehouseRequest struct {
+	Code    string  `json:"code"    validate:"required,min=1,max=32"`
+	Name    string  `json:"name"    validate:"required,min=1,max=128"`
+	Address *string `json:"address"`
+}
+
+type GetItemsRequest struct {
+	Name        *string           `json:"name"`
+	MinQuantity *int              `json:"min_quantity" validate:"omitempty,min=0"`
+	MaxQuantity *int              `json:"max_quantity" validate:"omitempty,min=0"`
+	MinPrice    *int              `json:"min_price"    validate:"omitempty,min=0"`
+	MaxPrice    *int              `json:"max_price"    validate:"omitempty,min=0"`
+	WarehouseID *string           `json:"warehouse_id"`
+	CategoryID  *string           `json:"category_id"`
+	Tags        []string          `json:"tags"         validate:"omitempty,max=10,dive,tag"`
+	Attributes  map[string]string `json:"attributes"   validate:"omitempty,max=10,dive,keys,attribute_key,endkeys"`
+	SortBy      *string           `json:"sort_by"`
+	SortOrder   *string           `json:"sort_order"`
+	Limit       int               `json:"limit"        validate:"min=1,max=500"`
+	Offset      int               `json:"offset"       validate:"min=0"`
+}
+
+type VerifyHistoryRequest struct {
+	HeadSeq  *int64  `json:"head_seq"  validate:"omitempty,min=1,required_with=HeadHash"`
+	HeadHash *string `json:"head_hash" validate:"omitempty,len=64,hexadecimal,required_with=HeadSeq"`
+}
+
+type GetTrashItemsRequest struct {
+	Limit  int `json:"limit"  validate:"min=1,max=500"`
+	Offset int `json:"offset" validate:"min=0"`
+}
+
+type GetLowStockItemsRequest struct {
+	Limit  int `json:"limit"  validate:"min=1,max=500"`
+	Offset int `json:"offset" validate:"min=0"`
+}
+
+type GetAlertsRequest struct {
+	Acknowledged *bool `json:"acknowledged"`
+	Limit        int   `json:"limit"        validate:"min=1,max=500"`
+	Offset       int   `json:"offset"       validate:"min=0"`
+}
+
+type CreateWebhookRequest struct {
+	URL      string   `json:"url"       validate:"required,http_url,max=2048"`
+	Events   []string `json:"events"    validate:"required,min=1,unique,dive,webhook_event"`
+	Secret   *string  `json:"secret"    validate:"omitempty,min=16,max=128"`
+	IsActive *bool    `json:"is_active"`
+}
+
+type UpdateWebhookRequest struct {
+	URL      *string   `json:"url"       validate:"omitempty,http_url,max=2048"`
+	Events   *[]string `json:"events"    validate:"omitempty,min=1,unique,dive,webhook_event"`
+	Secret   *string   `json:"secret"    validate:"omitempty,min=16,max=128"`
+	IsActive *bool     `json:"is_active"`
+}
+
+type GetWebhookDeliveriesRequest struct {
+	Status *string `json:"status" validate:"omitempty,oneof=pending delivered failed"`
+	Limit  int     `json:"limit"  validate:"min=1,max=500"`
+	Offset int     `json:"offset" validate:"min=0"`
 }
 
 type LoginRequest struct {
-	UserName string `json:"user_name" validate:"required,letters_only"`
+	UserName string `json:"user_name" validate:"required,letters_only,max=32"`
+	Password string `json:"password"  validate:"required,max=72"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken *string `json:"refresh_token"`
+}
+
+type RegisterRequest struct {
+	UserName string `json:"user_name" validate:"required,letters_only,max=32"`
+	Password string `json:"password"  validate:"required,min=8,max=72"`
+}
+
+type CreateUserRequest struct {
+	UserName string `json:"user_name" validate:"required,letters_only,max=32"`
+	Password string `json:"password"  validate:"required,min=8,max=72"`
 	Role     string `json:"role"      validate:"required,role"`
 }
 
+type UpdateUserRequest struct {
+	Role     *string `json:"role"     validate:"omitempty,role"`
+	Password *string `json:"password" validate:"omitempty,min=8,max=72"`
+	Disabled *bool   `json:"disabled"`
+
+	ChangeReason
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"       validate:"required,max=64"`
+	Role      string     `json:"role"       validate:"required,role"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type GetMovementsRequest struct {
+	ItemID      *string        `json:"item_id"`
+	UserID      *string        `json:"user_id"`
+	WarehouseID *string        `json:"warehouse_id"`
+	Kind        *string        `json:"kind"       validate:"omitempty,movement_kind"`
+	From        *time.Time     `json:"from"`
+	To          *time.Time     `json:"to"`
+	SortBy      *string        `json:"sort_by"`
+	SortOrder   *string        `json:"sort_order"`
+	Limit       int            `json:"limit"      validate:"min=0,max=500"`
+	Offset      int            `json:"offset"     validate:"min=0"`
+	Cursor      *cursor.Cursor `json:"cursor"`
+}
+
 type GetHistoryRequest struct {
-	ItemID    *string    `json:"item_id"`
-	UserID    *string    `json:"user_id"`
-	Action    *string    `json:"action"     validate:"omitempty,action_type"`
-	From      *time.Time `json:"from"`
-	To        *time.Time `json:"to"`
-	SortBy    *string    `json:"sort_by"`
-	SortOrder *string    `json:"sort_order"`
+	ItemID      *string        `json:"item_id"`
+	UserID      *string        `json:"user_id"`
+	WarehouseID *string        `json:"warehouse_id"`
+	LocationID  *string        `json:"location_id"`
+	Action      *string        `json:"action"     validate:"omitempty,action_type"`
+	ReasonCode  *string        `json:"reason_code"`
+	Reason      *string        `json:"reason"`
+	RequestID   *string        `json:"request_id"`
+	APIKeyID    *string        `json:"api_key_id"`
+	From        *time.Time     `json:"from"`
+	To          *time.Time     `json:"to"`
+	SortBy      *string        `json:"sort_by"`
+	SortOrder   *string        `json:"sort_order"`
+	Limit       int            `json:"limit"      validate:"min=0,max=500"`
+	Offset      int            `json:"offset"     validate:"min=0"`
+	Cursor      *cursor.Cursor `json:"cursor"`
 }
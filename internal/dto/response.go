@@ -1,38 +1,198 @@
 package dto
 
+import "encoding/json"
+
 type ItemResponse struct {
+	ID           string         `json:"id"`
+	SKU          string         `json:"sku"`
+	Barcodes     []string       `json:"barcodes"`
+	CategoryID   *string        `json:"category_id,omitempty"`
+	Name         string         `json:"name"`
+	Description  string         `json:"description"`
+	Attributes   map[string]any `json:"attributes"`
+	Tags         []string       `json:"tags"`
+	ReorderPoint *int           `json:"reorder_point,omitempty"`
+	Quantity     int            `json:"quantity"`
+	Price        string         `json:"price"`
+	Version      int            `json:"version"`
+	CreatedAt    string         `json:"created_at"`
+	UpdatedAt    string         `json:"updated_at"`
+	DeletedAt    *string        `json:"deleted_at,omitempty"`
+
+	Stocks    []ItemStockResponse    `json:"stocks,omitempty"`
+	Locations []ItemLocationResponse `json:"locations,omitempty"`
+}
+
+type ItemLocationResponse struct {
+	LocationID   string `json:"location_id"`
+	LocationCode string `json:"location_code"`
+	WarehouseID  string `json:"warehouse_id"`
+	Quantity     int    `json:"quantity"`
+}
+
+type LocationResponse struct {
 	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Quantity    int    `json:"quantity"`
-	Price       string `json:"price"`
+	WarehouseID string `json:"warehouse_id"`
+	Zone        string `json:"zone"`
+	Aisle       string `json:"aisle"`
+	Rack        string `json:"rack"`
+	Bin         string `json:"bin"`
+	Code        string `json:"code"`
 	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+}
+
+type LocationListResponse struct {
+	Locations []LocationResponse `json:"locations"`
+	Total     int                `json:"total"`
+}
+
+type LocationContentResponse struct {
+	ItemID   string `json:"item_id"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+}
+
+type LocationContentListResponse struct {
+	Location LocationResponse          `json:"location"`
+	Items    []LocationContentResponse `json:"items"`
+	Total    int                       `json:"total"`
+}
+
+type ItemLocationsResponse struct {
+	ItemID    string                 `json:"item_id"`
+	Locations []ItemLocationResponse `json:"locations"`
+	Message   string                 `json:"message,omitempty"`
+}
+
+type ItemStockResponse struct {
+	WarehouseID   string `json:"warehouse_id"`
+	WarehouseCode string `json:"warehouse_code"`
+	WarehouseName string `json:"warehouse_name"`
+	Quantity      int    `json:"quantity"`
+}
+
+type WarehouseResponse struct {
+	ID        string  `json:"id"`
+	Code      string  `json:"code"`
+	Name      string  `json:"name"`
+	Address   *string `json:"address,omitempty"`
+	IsDefault bool    `json:"is_default"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type WarehouseListResponse struct {
+	Warehouses []WarehouseResponse `json:"warehouses"`
+	Total      int                 `json:"total"`
+}
+
+type TransferResponse struct {
+	ItemID          string              `json:"item_id"`
+	FromWarehouseID string              `json:"from_warehouse_id"`
+	ToWarehouseID   string              `json:"to_warehouse_id"`
+	Quantity        int                 `json:"quantity"`
+	Stocks          []ItemStockResponse `json:"stocks"`
+	Message         string              `json:"message,omitempty"`
 }
 
 type ItemsListResponse struct {
+	Items  []ItemResponse `json:"items"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit,omitempty"`
+	Offset int            `json:"offset,omitempty"`
+}
+
+type ItemsSnapshotResponse struct {
+	At    string         `json:"at"`
 	Items []ItemResponse `json:"items"`
 	Total int            `json:"total"`
 }
 
 type HistoryResponse struct {
-	ID        string         `json:"id"`
-	ItemID    string         `json:"item_id"`
-	Action    string         `json:"action"`
-	UserID    *string        `json:"user_id,omitempty"`
-	ChangedAt string         `json:"changed_at"`
-	OldData   map[string]any `json:"old_data,omitempty"`
-	NewData   map[string]any `json:"new_data,omitempty"`
+	ID            string         `json:"id"`
+	ItemID        string         `json:"item_id"`
+	Action        string         `json:"action"`
+	UserID        *string        `json:"user_id,omitempty"`
+	ChangedAt     string         `json:"changed_at"`
+	OldData       map[string]any `json:"old_data,omitempty"`
+	NewData       map[string]any `json:"new_data,omitempty"`
+	QuantityDelta *int           `json:"quantity_delta,omitempty"`
+	ReasonCode    *string        `json:"reason_code,omitempty"`
+	Reference     *string        `json:"reference,omitempty"`
+	WarehouseID   *string        `json:"warehouse_id,omitempty"`
+	LocationID    *string        `json:"location_id,omitempty"`
+	RevertedFrom  *string        `json:"reverted_from,omitempty"`
+	Reason        *string        `json:"reason,omitempty"`
+	RequestID     *string        `json:"request_id,omitempty"`
+	APIKeyID      *string        `json:"api_key_id,omitempty"`
 }
 
 type HistoryListResponse struct {
-	History []HistoryResponse `json:"history"`
-	Total   int               `json:"total"`
+	History    []HistoryResponse `json:"history"`
+	Total      int               `json:"total"`
+	Limit      int               `json:"limit,omitempty"`
+	Offset     int               `json:"offset,omitempty"`
+	NextCursor *string           `json:"next_cursor,omitempty"`
 }
 
 type LoginResponse struct {
-	Token string `json:"token"`
-	Role  string `json:"role"`
+	Token        string `json:"token"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Role         string `json:"role"`
+}
+
+type UserResponse struct {
+	ID         string  `json:"id"`
+	UserName   string  `json:"user_name"`
+	Role       string  `json:"role"`
+	Disabled   bool    `json:"disabled"`
+	DisabledAt *string `json:"disabled_at,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+	UpdatedAt  string  `json:"updated_at"`
+}
+
+type UserListResponse struct {
+	Users []UserResponse `json:"users"`
+	Total int            `json:"total"`
+}
+
+type UserHistoryResponse struct {
+	ID         string         `json:"id"`
+	UserID     string         `json:"user_id"`
+	Action     string         `json:"action"`
+	ChangedBy  *string        `json:"changed_by,omitempty"`
+	ChangedAt  string         `json:"changed_at"`
+	OldData    map[string]any `json:"old_data,omitempty"`
+	NewData    map[string]any `json:"new_data,omitempty"`
+	ReasonCode *string        `json:"reason_code,omitempty"`
+	Reason     *string        `json:"reason,omitempty"`
+	RequestID  *string        `json:"request_id,omitempty"`
+	APIKeyID   *string        `json:"api_key_id,omitempty"`
+}
+
+type APIKeyResponse struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Role       string  `json:"role"`
+	KeyPrefix  string  `json:"key_prefix"`
+	Key        string  `json:"key,omitempty"`
+	ExpiresAt  *string `json:"expires_at,omitempty"`
+	LastUsedAt *string `json:"last_used_at,omitempty"`
+	CreatedBy  *string `json:"created_by,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+	Revoked    bool    `json:"revoked"`
+	RevokedAt  *string `json:"revoked_at,omitempty"`
+}
+
+type APIKeyListResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+	Total   int              `json:"total"`
+}
+
+type UserHistoryListResponse struct {
+	History []UserHistoryResponse `json:"history"`
+	Total   int                   `json:"total"`
 }
 
 type DiffResponse struct {
@@ -48,18 +208,91 @@ type HistoryWithDiffResponse struct {
 }
 
 type HistoryWithDiffListResponse struct {
-	History []HistoryWithDiffResponse `json:"history"`
-	Total   int                       `json:"total"`
+	History    []HistoryWithDiffResponse `json:"history"`
+	Total      int                       `json:"total"`
+	Limit      int                       `json:"limit,omitempty"`
+	Offset     int                       `json:"offset,omitempty"`
+	NextCursor *string                   `json:"next_cursor,omitempty"`
 }
 
 type HistoryExportResponse struct {
-	ID        string `json:"id"`
-	ItemID    string `json:"item_id"`
-	Action    string `json:"action"`
-	UserID    string `json:"user_id"`
-	ChangedAt string `json:"changed_at"`
-	OldData   string `json:"old_data"`
-	NewData   string `json:"new_data"`
+	ID            string `json:"id"`
+	ItemID        string `json:"item_id"`
+	Action        string `json:"action"`
+	UserID        string `json:"user_id"`
+	ChangedAt     string `json:"changed_at"`
+	OldData       string `json:"old_data"`
+	NewData       string `json:"new_data"`
+	QuantityDelta string `json:"quantity_delta"`
+	ReasonCode    string `json:"reason_code"`
+	Reference     string `json:"reference"`
+	WarehouseID   string `json:"warehouse_id"`
+	LocationID    string `json:"location_id"`
+	RevertedFrom  string `json:"reverted_from"`
+	Reason        string `json:"reason"`
+	RequestID     string `json:"request_id"`
+	APIKeyID      string `json:"api_key_id"`
+}
+
+type StockAdjustmentResponse struct {
+	ItemID   string `json:"item_id"`
+	Quantity int    `json:"quantity"`
+	Delta    int    `json:"delta"`
+	Version  int    `json:"version"`
+	Message  string `json:"message,omitempty"`
+}
+
+type MovementResponse struct {
+	ID          string  `json:"id"`
+	ItemID      string  `json:"item_id"`
+	WarehouseID *string `json:"warehouse_id,omitempty"`
+	Kind        string  `json:"kind"`
+	Quantity    int     `json:"quantity"`
+	Reference   *string `json:"reference,omitempty"`
+	UserID      *string `json:"user_id,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+type MovementListResponse struct {
+	Movements     []MovementResponse `json:"movements"`
+	Total         int                `json:"total"`
+	TotalQuantity int                `json:"total_quantity"`
+	Limit         int                `json:"limit,omitempty"`
+	Offset        int                `json:"offset,omitempty"`
+	NextCursor    *string            `json:"next_cursor,omitempty"`
+}
+
+type StockDiscrepancyResponse struct {
+	ItemID         string `json:"item_id"`
+	Name           string `json:"name"`
+	Quantity       int    `json:"quantity"`
+	LedgerQuantity int    `json:"ledger_quantity"`
+	Difference     int    `json:"difference"`
+}
+
+type HistoryChainBreakResponse struct {
+	HistoryID string `json:"history_id,omitempty"`
+	ChainSeq  int64  `json:"chain_seq"`
+	ChangedAt string `json:"changed_at,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+type HistoryVerifyResponse struct {
+	Valid    bool                       `json:"valid"`
+	Verified int                        `json:"verified"`
+	HeadSeq  *int64                     `json:"head_seq"`
+	HeadHash *string                    `json:"head_hash"`
+	Broken   *HistoryChainBreakResponse `json:"broken,omitempty"`
+}
+
+type StockDiscrepancyListResponse struct {
+	Items []StockDiscrepancyResponse `json:"items"`
+	Total int                        `json:"total"`
+}
+
+type ItemConflictResponse struct {
+	Error   string       `json:"error"`
+	Current ItemResponse `json:"current"`
 }
 
 type ItemWithMessageResponse struct {
@@ -67,3 +300,86 @@ type ItemWithMessageResponse struct {
 
 	Message string `json:"message,omitempty"`
 }
+
+type CategoryResponse struct {
+	ID        string  `json:"id"`
+	ParentID  *string `json:"parent_id,omitempty"`
+	Name      string  `json:"name"`
+	Path      string  `json:"path"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type CategoryListResponse struct {
+	Categories []CategoryResponse `json:"categories"`
+	Total      int                `json:"total"`
+}
+
+type AlertResponse struct {
+	ID                   string  `json:"id"`
+	ItemID               string  `json:"item_id"`
+	ItemSKU              string  `json:"item_sku"`
+	ItemName             string  `json:"item_name"`
+	Kind                 string  `json:"kind"`
+	Quantity             int     `json:"quantity"`
+	ReorderPoint         int     `json:"reorder_point"`
+	CreatedAt            string  `json:"created_at"`
+	AcknowledgedAt       *string `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy       *string `json:"acknowledged_by,omitempty"`
+	APIKeyID             *string `json:"api_key_id,omitempty"`
+	AcknowledgedAPIKeyID *string `json:"acknowledged_api_key_id,omitempty"`
+}
+
+type AlertListResponse struct {
+	Alerts []AlertResponse `json:"alerts"`
+	Total  int             `json:"total"`
+	Limit  int             `json:"limit,omitempty"`
+	Offset int             `json:"offset,omitempty"`
+}
+
+type WebhookResponse struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Secret    string   `json:"secret,omitempty"`
+	IsActive  bool     `json:"is_active"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type WebhookListResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+	Total    int               `json:"total"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string  `json:"id"`
+	SubscriptionID string  `json:"subscription_id"`
+	EventID        string  `json:"event_id"`
+	EventType      string  `json:"event_type"`
+	ItemID         string  `json:"item_id"`
+	Status         string  `json:"status"`
+	Attempts       int     `json:"attempts"`
+	NextAttemptAt  *string `json:"next_attempt_at,omitempty"`
+	ResponseStatus *int    `json:"response_status,omitempty"`
+	LastError      *string `json:"last_error,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+	DeliveredAt    *string `json:"delivered_at,omitempty"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+	Total      int                       `json:"total"`
+	Limit      int                       `json:"limit,omitempty"`
+	Offset     int                       `json:"offset,omitempty"`
+}
+
+type WebhookEventPayload struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt string          `json:"occurred_at"`
+	ItemID     string          `json:"item_id"`
+	UserID     *string         `json:"user_id,omitempty"`
+	APIKeyID   *string         `json:"api_key_id,omitempty"`
+	Data       json.RawMessage `json:"data"`
+}
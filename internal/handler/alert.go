@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/converter"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/middleware"
+)
+
+func (h *Handler) getAlertsHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.GetAlertsRequest
+
+	if err := parseAlertsQuery(r, &req); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	result, total, err := h.service.GetAlerts(r.Context(), req)
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.AlertListResponse{
+		Alerts: converter.AlertsToResponse(result),
+		Total:  total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+}
+
+func (h *Handler) acknowledgeAlertHandler(w http.ResponseWriter, r *http.Request) {
+	alertID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var userID *uuid.UUID
+	if id, ok := middleware.UserIDFromContext(r.Context()); ok {
+		userID = id
+	}
+
+	result, err := h.service.AcknowledgeAlert(r.Context(), alertID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrAlertNotFound):
+			h.respondError(w, http.StatusNotFound, "alert not found")
+		case errors.Is(err, apperrors.ErrAlertAcknowledged):
+			h.respondError(w, http.StatusConflict, "alert already acknowledged")
+		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.AlertToResponse(result))
+}
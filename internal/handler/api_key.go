@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/converter"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/middleware"
+)
+
+func (h *Handler) createAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateAPIKeyRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	var actorID *uuid.UUID
+	if id, ok := middleware.UserIDFromContext(r.Context()); ok {
+		actorID = id
+	}
+
+	result, rawKey, err := h.service.CreateAPIKey(r.Context(), req, actorID)
+	if err != nil {
+		h.respondAPIKeyError(w, err)
+		return
+	}
+
+	resp := converter.APIKeyToResponse(result)
+	resp.Key = rawKey
+	h.respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *Handler) getAPIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.GetAPIKeys(r.Context())
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := converter.APIKeysToResponse(result)
+	h.respondJSON(w, http.StatusOK, dto.APIKeyListResponse{
+		APIKeys: resp,
+		Total:   len(resp),
+	})
+}
+
+func (h *Handler) getAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.service.GetAPIKeyByID(r.Context(), id)
+	if err != nil {
+		h.respondAPIKeyError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.APIKeyToResponse(result))
+}
+
+func (h *Handler) revokeAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.service.RevokeAPIKey(r.Context(), id)
+	if err != nil {
+		h.respondAPIKeyError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.APIKeyToResponse(result))
+}
+
+func (h *Handler) respondAPIKeyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrAPIKeyNotFound):
+		h.respondError(w, http.StatusNotFound, "api key not found")
+	case errors.Is(err, apperrors.ErrAPIKeyExpiresInPast):
+		h.respondError(w, http.StatusBadRequest, "expires_at must be in the future")
+	default:
+		h.responseLogger(w).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -3,13 +3,16 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 
 	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/converter"
 	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/middleware"
 )
 
-func (h *Handler) SignInOrSignUp(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) loginHandler(w http.ResponseWriter, r *http.Request) {
 	var req dto.LoginRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -22,21 +25,102 @@ func (h *Handler) SignInOrSignUp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, role, err := h.service.SignInOrSignUp(r.Context(), req.UserName, req.Role)
+	result, err := h.service.SignIn(r.Context(), req)
 	if err != nil {
 		switch {
-		case errors.Is(err, apperrors.ErrUserAlreadyExists):
-			h.respondError(w, http.StatusConflict, "user already exists")
-		case errors.Is(err, apperrors.ErrRoleMismatch):
-			h.respondError(w, http.StatusConflict, "user already exists with role")
+		case errors.Is(err, apperrors.ErrInvalidCredentials):
+			h.respondError(w, http.StatusUnauthorized, "invalid user name or password")
+		case errors.Is(err, apperrors.ErrUserDisabled):
+			h.respondError(w, http.StatusForbidden, "user is disabled")
 		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
 			h.respondError(w, http.StatusInternalServerError, "internal server error")
 		}
 		return
 	}
 
-	h.respondJSON(w, http.StatusOK, dto.LoginResponse{
-		Token: token,
-		Role:  role,
-	})
+	h.respondJSON(w, http.StatusOK, converter.AuthTokensToResponse(result))
+}
+
+func (h *Handler) refreshTokenHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.RefreshTokenRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	result, err := h.service.RefreshTokens(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidRefreshToken):
+			h.respondError(w, http.StatusUnauthorized, "invalid or expired refresh token")
+		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.AuthTokensToResponse(result))
+}
+
+func (h *Handler) logoutHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.LogoutRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims, ok := middleware.ClaimsFromContext(r.Context())
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.service.Logout(r.Context(), claims, req); err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]string{"message": "logged out successfully"})
+}
+
+func (h *Handler) registerHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.RegisterRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	result, err := h.service.Register(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrRegistrationDisabled):
+			h.respondError(w, http.StatusForbidden, "self-registration is disabled")
+		default:
+			h.respondUserError(w, err)
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, converter.UserToResponse(result))
+}
+
+func (h *Handler) jwksHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	h.respondJSON(w, http.StatusOK, h.tokenValidator.JWKS())
 }
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/converter"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+)
+
+func (h *Handler) createCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	result, err := h.service.CreateCategory(r.Context(), req)
+	if err != nil {
+		h.respondCategoryError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, converter.CategoryToResponse(result))
+}
+
+func (h *Handler) getCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.GetCategories(r.Context())
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := converter.CategoriesToResponse(result)
+	h.respondJSON(w, http.StatusOK, dto.CategoryListResponse{
+		Categories: resp,
+		Total:      len(resp),
+	})
+}
+
+func (h *Handler) getCategoryByIDHandler(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.service.GetCategoryByID(r.Context(), categoryID)
+	if err != nil {
+		h.respondCategoryError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.CategoryToResponse(result))
+}
+
+func (h *Handler) updateCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req dto.UpdateCategoryRequest
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errValidate := h.valid.Struct(req); errValidate != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(errValidate))
+		return
+	}
+
+	result, err := h.service.UpdateCategory(r.Context(), categoryID, req)
+	if err != nil {
+		h.respondCategoryError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.CategoryToResponse(result))
+}
+
+func (h *Handler) deleteCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err = h.service.DeleteCategory(r.Context(), categoryID); err != nil {
+		h.respondCategoryError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]string{"message": "category deleted successfully"})
+}
+
+func (h *Handler) respondCategoryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrCategoryNotFound):
+		h.respondError(w, http.StatusNotFound, "category not found")
+	case errors.Is(err, apperrors.ErrParentNotFound):
+		h.respondError(w, http.StatusNotFound, "parent category not found")
+	case errors.Is(err, apperrors.ErrCategoryExists):
+		h.respondError(w, http.StatusConflict, "category with this name already exists at this level")
+	case errors.Is(err, apperrors.ErrCategoryCycle):
+		h.respondError(w, http.StatusBadRequest, "category cannot be moved into its own subtree")
+	case errors.Is(err, apperrors.ErrCategoryInUse):
+		h.respondError(w, http.StatusConflict, "category has items or subcategories")
+	default:
+		h.responseLogger(w).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
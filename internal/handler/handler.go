@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -38,6 +39,7 @@ func NewHandler(
 
 func (h *Handler) NewRouter() http.Handler {
 	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
 	r.Use(middleware.CORS)
 
 	h.registerPublicRoutes(r)
@@ -47,6 +49,10 @@ func (h *Handler) NewRouter() http.Handler {
 	return r
 }
 
+func (h *Handler) logger(ctx context.Context) *slog.Record {
+	return h.log.WithCtx(ctx)
+}
+
 func (h *Handler) serveHTML(filename string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		http.ServeFile(w, r, "./web/"+filename)
@@ -24,6 +24,24 @@ func (h *Handler) validateUUIDParams(req *dto.GetHistoryRequest) error {
 		}
 	}
 
+	if req.WarehouseID != nil {
+		if _, err := uuid.Parse(*req.WarehouseID); err != nil {
+			return fmt.Errorf("invalid warehouse_id: %w", err)
+		}
+	}
+
+	if req.LocationID != nil {
+		if _, err := uuid.Parse(*req.LocationID); err != nil {
+			return fmt.Errorf("invalid location_id: %w", err)
+		}
+	}
+
+	if req.APIKeyID != nil {
+		if _, err := uuid.Parse(*req.APIKeyID); err != nil {
+			return fmt.Errorf("invalid api_key_id: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -34,23 +52,37 @@ func (h *Handler) getItemHistoryHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	result, err := h.service.GetHistoryByItemID(r.Context(), id)
+	var req dto.GetHistoryRequest
+	if errParse := parseHistoryPageQuery(r, &req); errParse != nil {
+		h.respondError(w, http.StatusBadRequest, errParse.Error())
+		return
+	}
+
+	if errValidate := h.valid.Struct(req); errValidate != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(errValidate))
+		return
+	}
+
+	result, err := h.service.GetHistoryByItemID(r.Context(), id, req)
 	if err != nil {
 		switch {
 		case errors.Is(err, apperrors.ErrItemNotFound):
 			h.respondError(w, http.StatusNotFound, "item not found")
 		default:
-			h.log.Errorf("Service error: %v", err)
+			h.logger(r.Context()).Errorf("Service error: %v", err)
 			h.respondError(w, http.StatusInternalServerError, "internal server error")
 		}
 		return
 	}
 
-	resp := converter.HistoriesToResponseWithDiff(result)
+	resp := converter.HistoriesToResponseWithDiff(result.Histories)
 
 	h.respondJSON(w, http.StatusOK, dto.HistoryWithDiffListResponse{
-		History: resp,
-		Total:   len(resp),
+		History:    resp,
+		Total:      result.Total,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+		NextCursor: result.NextCursor,
 	})
 }
 
@@ -62,6 +94,11 @@ func (h *Handler) getHistoryHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := parseHistoryPageQuery(r, &req); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	if err := h.valid.Struct(req); err != nil {
 		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
 		return
@@ -72,22 +109,25 @@ func (h *Handler) getHistoryHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, total, err := h.service.GetHistory(r.Context(), req)
+	result, err := h.service.GetHistory(r.Context(), req)
 	if err != nil {
 		switch {
 		case errors.Is(err, apperrors.ErrItemNotFound):
 			h.respondError(w, http.StatusNotFound, "item not found")
 		default:
-			h.log.Errorf("Service error: %v", err)
+			h.logger(r.Context()).Errorf("Service error: %v", err)
 			h.respondError(w, http.StatusInternalServerError, "internal server error")
 		}
 		return
 	}
 
-	resp := converter.HistoriesToResponse(result)
+	resp := converter.HistoriesToResponse(result.Histories)
 	h.respondJSON(w, http.StatusOK, dto.HistoryListResponse{
-		History: resp,
-		Total:   total,
+		History:    resp,
+		Total:      result.Total,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+		NextCursor: result.NextCursor,
 	})
 }
 
@@ -115,7 +155,7 @@ func (h *Handler) exportHistoryHandler(w http.ResponseWriter, r *http.Request) {
 		case errors.Is(err, apperrors.ErrItemNotFound):
 			h.respondError(w, http.StatusNotFound, "item not found")
 		default:
-			h.log.Errorf("Service error: %v", err)
+			h.logger(r.Context()).Errorf("Service error: %v", err)
 			h.respondError(w, http.StatusInternalServerError, "internal server error")
 		}
 		return
@@ -123,3 +163,35 @@ func (h *Handler) exportHistoryHandler(w http.ResponseWriter, r *http.Request) {
 
 	h.respondCSV(w, http.StatusOK, data)
 }
+
+func (h *Handler) verifyHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.VerifyHistoryRequest
+
+	if err := parseVerifyHistoryQuery(r, &req); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	result, err := h.service.VerifyHistory(r.Context(), req)
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	if result.Broken != nil {
+		h.logger(r.Context()).Errorf(
+			"History chain broken at %s (chain_seq=%d): %s",
+			result.Broken.HistoryID,
+			result.Broken.ChainSeq,
+			result.Broken.Reason,
+		)
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.HistoryVerificationToResponse(result))
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/converter"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/pkg/cursor"
+)
+
+const (
+	historyStreamRetry     = 3 * time.Second
+	historyStreamHeartbeat = 25 * time.Second
+	historyReplayBatch     = 200
+)
+
+func (h *Handler) streamHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.GetHistoryRequest
+
+	if err := parseHistoryQuery(r, &req); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	if err := h.validateUUIDParams(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lastEventID, err := parseLastEventID(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.respondError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	sub := h.service.SubscribeHistory(req)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", historyStreamRetry.Milliseconds())
+	flusher.Flush()
+
+	replayed := make(map[uuid.UUID]struct{})
+	if lastEventID != nil {
+		if err = h.replayHistory(r.Context(), w, req, lastEventID, replayed); err != nil {
+			h.logger(r.Context()).Errorf("History stream replay: %v", err)
+			return
+		}
+		flusher.Flush()
+	}
+
+	heartbeat := time.NewTicker(historyStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case history, open := <-sub.Events():
+			if !open {
+				return
+			}
+			if _, seen := replayed[history.ID]; seen {
+				continue
+			}
+			if err = writeHistoryEvent(w, history); err != nil {
+				h.logger(r.Context()).Errorf("History stream: %v", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (h *Handler) replayHistory(
+	ctx context.Context,
+	w http.ResponseWriter,
+	req dto.GetHistoryRequest,
+	after *cursor.Cursor,
+	replayed map[uuid.UUID]struct{},
+) error {
+	sortBy, sortOrder := "changed_at", "ASC"
+	req.SortBy, req.SortOrder = &sortBy, &sortOrder
+	req.Limit, req.Offset, req.Cursor = historyReplayBatch, 0, after
+
+	for {
+		page, err := h.service.GetHistory(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		for _, history := range page.Histories {
+			if err = writeHistoryEvent(w, history); err != nil {
+				return err
+			}
+			replayed[history.ID] = struct{}{}
+		}
+
+		if page.NextCursor == nil {
+			return nil
+		}
+
+		if req.Cursor, err = cursor.Decode(*page.NextCursor); err != nil {
+			return err
+		}
+	}
+}
+
+func writeHistoryEvent(w http.ResponseWriter, history *models.History) error {
+	data, err := json.Marshal(converter.HistoryToResponseWithDiff(history))
+	if err != nil {
+		return fmt.Errorf("marshal history event: %w", err)
+	}
+
+	id := cursor.Encode(cursor.Cursor{Time: history.ChangedAt, ID: history.ID})
+	if _, err = fmt.Fprintf(w, "id: %s\nevent: history\ndata: %s\n\n", id, data); err != nil {
+		return fmt.Errorf("write history event: %w", err)
+	}
+
+	return nil
+}
+
+func parseLastEventID(r *http.Request) (*cursor.Cursor, error) {
+	value := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+	if value == "" {
+		value = strings.TrimSpace(r.URL.Query().Get("last_event_id"))
+	}
+	if value == "" {
+		return nil, nil //nolint:nilnil // absent header is not an error
+	}
+
+	c, err := cursor.Decode(value)
+	if err != nil {
+		return nil, errors.New("invalid Last-Event-ID")
+	}
+
+	return c, nil
+}
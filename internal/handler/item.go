@@ -5,11 +5,13 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
 	"github.com/kstsm/wb-warehouse-control/internal/converter"
 	"github.com/kstsm/wb-warehouse-control/internal/dto"
 	"github.com/kstsm/wb-warehouse-control/internal/middleware"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
 )
 
 func (h *Handler) createItemHandler(w http.ResponseWriter, r *http.Request) {
@@ -34,8 +36,16 @@ func (h *Handler) createItemHandler(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case errors.Is(err, apperrors.ErrItemNotFound):
 			h.respondError(w, http.StatusNotFound, "item not found")
+		case errors.Is(err, apperrors.ErrWarehouseNotFound):
+			h.respondError(w, http.StatusNotFound, "warehouse not found")
+		case errors.Is(err, apperrors.ErrCategoryNotFound):
+			h.respondError(w, http.StatusNotFound, "category not found")
+		case errors.Is(err, apperrors.ErrSKUExists):
+			h.respondError(w, http.StatusConflict, "item with this sku already exists")
+		case errors.Is(err, apperrors.ErrBarcodeExists):
+			h.respondError(w, http.StatusConflict, "barcode already assigned to another item")
 		default:
-			h.log.Errorf("Service error: %v", err)
+			h.logger(r.Context()).Errorf("Service error: %v", err)
 			h.respondError(w, http.StatusInternalServerError, "internal server error")
 		}
 		return
@@ -61,33 +71,145 @@ func (h *Handler) getItemByIDHandler(w http.ResponseWriter, r *http.Request) {
 		case errors.Is(err, apperrors.ErrItemNotFound):
 			h.respondError(w, http.StatusNotFound, "item not found")
 		default:
-			h.log.Errorf("Service error: %v", err)
+			h.logger(r.Context()).Errorf("Service error: %v", err)
 			h.respondError(w, http.StatusInternalServerError, "internal server error")
 		}
 		return
 	}
 
 	resp := converter.ItemToResponse(result)
+	setETag(w, result.Version)
 	h.respondJSON(w, http.StatusOK, resp)
 }
 
-func (h *Handler) getItemsHandler(w http.ResponseWriter, r *http.Request) {
-	result, err := h.service.GetItems(r.Context())
+func (h *Handler) getItemBySKUHandler(w http.ResponseWriter, r *http.Request) {
+	sku := chi.URLParam(r, "sku")
+	if err := h.valid.Var(sku, "required,sku"); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid sku")
+		return
+	}
+
+	result, err := h.service.GetItemBySKU(r.Context(), sku)
+	h.respondItemLookup(w, result, err)
+}
+
+func (h *Handler) getItemByBarcodeHandler(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	if err := h.valid.Var(code, "required,barcode"); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid barcode")
+		return
+	}
+
+	result, err := h.service.GetItemByBarcode(r.Context(), code)
+	h.respondItemLookup(w, result, err)
+}
+
+func (h *Handler) respondItemLookup(w http.ResponseWriter, result *models.Item, err error) {
 	if err != nil {
 		switch {
 		case errors.Is(err, apperrors.ErrItemNotFound):
 			h.respondError(w, http.StatusNotFound, "item not found")
 		default:
-			h.log.Errorf("Service error: %v", err)
+			h.responseLogger(w).Errorf("Service error: %v", err)
 			h.respondError(w, http.StatusInternalServerError, "internal server error")
 		}
 		return
 	}
 
+	if result.DeletedAt != nil {
+		h.respondJSON(w, http.StatusGone, dto.ItemConflictResponse{
+			Error:   "item is in the trash",
+			Current: converter.ItemToResponse(result),
+		})
+		return
+	}
+
+	setETag(w, result.Version)
+	h.respondJSON(w, http.StatusOK, converter.ItemToResponse(result))
+}
+
+func (h *Handler) getItemsHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.GetItemsRequest
+
+	if err := parseItemsQuery(r, &req); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	result, total, err := h.service.GetItems(r.Context(), req)
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
 	resp := converter.ItemsToResponse(result)
 	h.respondJSON(w, http.StatusOK, dto.ItemsListResponse{
-		Items: resp,
-		Total: len(resp),
+		Items:  resp,
+		Total:  total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+}
+
+func (h *Handler) getTrashItemsHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.GetTrashItemsRequest
+
+	if err := parseTrashItemsQuery(r, &req); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	result, total, err := h.service.GetTrashItems(r.Context(), req)
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.ItemsListResponse{
+		Items:  converter.ItemsToResponse(result),
+		Total:  total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+}
+
+func (h *Handler) getLowStockItemsHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.GetLowStockItemsRequest
+
+	if err := parseLowStockItemsQuery(r, &req); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	result, total, err := h.service.GetLowStockItems(r.Context(), req)
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.ItemsListResponse{
+		Items:  converter.ItemsToResponse(result),
+		Total:  total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
 	})
 }
 
@@ -104,6 +226,15 @@ func (h *Handler) updateItemHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ifMatch, err := parseIfMatch(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if ifMatch != nil {
+		req.Version = ifMatch
+	}
+
 	if errValidate := h.valid.Struct(req); errValidate != nil {
 		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(errValidate))
 		return
@@ -119,20 +250,53 @@ func (h *Handler) updateItemHandler(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case errors.Is(err, apperrors.ErrItemNotFound):
 			h.respondError(w, http.StatusNotFound, "item not found")
+		case errors.Is(err, apperrors.ErrVersionConflict):
+			h.respondVersionConflict(w, r, itemID)
+		case errors.Is(err, apperrors.ErrWarehouseNotFound):
+			h.respondError(w, http.StatusNotFound, "warehouse not found")
+		case errors.Is(err, apperrors.ErrCategoryNotFound):
+			h.respondError(w, http.StatusNotFound, "category not found")
+		case errors.Is(err, apperrors.ErrInsufficientStock):
+			h.respondError(w, http.StatusConflict, "insufficient stock in warehouse")
+		case errors.Is(err, apperrors.ErrSKUExists):
+			h.respondError(w, http.StatusConflict, "item with this sku already exists")
+		case errors.Is(err, apperrors.ErrBarcodeExists):
+			h.respondError(w, http.StatusConflict, "barcode already assigned to another item")
 		default:
-			h.log.Errorf("Service error: %v", err)
+			h.logger(r.Context()).Errorf("Service error: %v", err)
 			h.respondError(w, http.StatusInternalServerError, "internal server error")
 		}
 		return
 	}
 
 	resp := converter.ItemToResponse(result)
+	setETag(w, result.Version)
 	h.respondJSON(w, http.StatusOK, dto.ItemWithMessageResponse{
 		ItemResponse: resp,
 		Message:      "item updated successfully",
 	})
 }
 
+func (h *Handler) respondVersionConflict(w http.ResponseWriter, r *http.Request, itemID uuid.UUID) {
+	current, err := h.service.GetItemByID(r.Context(), itemID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrItemNotFound):
+			h.respondError(w, http.StatusNotFound, "item not found")
+		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	setETag(w, current.Version)
+	h.respondJSON(w, http.StatusPreconditionFailed, dto.ItemConflictResponse{
+		Error:   "item was modified by another request",
+		Current: converter.ItemToResponse(current),
+	})
+}
+
 func (h *Handler) deleteItemHandler(w http.ResponseWriter, r *http.Request) {
 	itemID, err := parseUUIDParam(r)
 	if err != nil {
@@ -140,18 +304,24 @@ func (h *Handler) deleteItemHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	reason := parseChangeReasonQuery(r)
+	if errValidate := h.valid.Struct(reason); errValidate != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(errValidate))
+		return
+	}
+
 	var userID *uuid.UUID
 	if id, ok := middleware.UserIDFromContext(r.Context()); ok {
 		userID = id
 	}
 
-	err = h.service.DeleteItem(r.Context(), itemID, userID)
+	err = h.service.DeleteItem(r.Context(), itemID, reason, userID)
 	if err != nil {
 		switch {
 		case errors.Is(err, apperrors.ErrItemNotFound):
 			h.respondError(w, http.StatusNotFound, "item not found")
 		default:
-			h.log.Errorf("Service error: %v", err)
+			h.logger(r.Context()).Errorf("Service error: %v", err)
 			h.respondError(w, http.StatusInternalServerError, "internal server error")
 		}
 		return
@@ -159,3 +329,151 @@ func (h *Handler) deleteItemHandler(w http.ResponseWriter, r *http.Request) {
 
 	h.respondJSON(w, http.StatusOK, map[string]string{"message": "item deleted successfully"})
 }
+
+func (h *Handler) restoreItemHandler(w http.ResponseWriter, r *http.Request) {
+	itemID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reason := parseChangeReasonQuery(r)
+	if errValidate := h.valid.Struct(reason); errValidate != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(errValidate))
+		return
+	}
+
+	var userID *uuid.UUID
+	if id, ok := middleware.UserIDFromContext(r.Context()); ok {
+		userID = id
+	}
+
+	result, err := h.service.RestoreItem(r.Context(), itemID, reason, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrItemNotFound):
+			h.respondError(w, http.StatusNotFound, "item not found in trash")
+		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := converter.ItemToResponse(result)
+	h.respondJSON(w, http.StatusOK, dto.ItemWithMessageResponse{
+		ItemResponse: resp,
+		Message:      "item restored successfully",
+	})
+}
+
+func (h *Handler) revertItemHandler(w http.ResponseWriter, r *http.Request) {
+	itemID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req dto.RevertItemRequest
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ifMatch, err := parseIfMatch(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if ifMatch != nil {
+		req.Version = ifMatch
+	}
+
+	if errValidate := h.valid.Struct(req); errValidate != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(errValidate))
+		return
+	}
+
+	var userID *uuid.UUID
+	if id, ok := middleware.UserIDFromContext(r.Context()); ok {
+		userID = id
+	}
+
+	result, err := h.service.RevertItem(r.Context(), itemID, req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrHistoryNotFound):
+			h.respondError(w, http.StatusNotFound, "history entry not found for this item")
+		case errors.Is(err, apperrors.ErrNotRevertible):
+			h.respondError(w, http.StatusBadRequest, "history entry has no item snapshot to revert to")
+		case errors.Is(err, apperrors.ErrVersionConflict):
+			h.respondVersionConflict(w, r, itemID)
+		case errors.Is(err, apperrors.ErrWarehouseNotFound):
+			h.respondError(w, http.StatusNotFound, "warehouse not found")
+		case errors.Is(err, apperrors.ErrCategoryNotFound):
+			h.respondError(w, http.StatusConflict, "category from the snapshot no longer exists")
+		case errors.Is(err, apperrors.ErrInsufficientStock):
+			h.respondError(w, http.StatusConflict, "insufficient stock in warehouse")
+		case errors.Is(err, apperrors.ErrSKUExists):
+			h.respondError(w, http.StatusConflict, "item with this sku already exists")
+		case errors.Is(err, apperrors.ErrBarcodeExists):
+			h.respondError(w, http.StatusConflict, "barcode already assigned to another item")
+		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := converter.ItemToResponse(result)
+	setETag(w, result.Version)
+	h.respondJSON(w, http.StatusOK, dto.ItemWithMessageResponse{
+		ItemResponse: resp,
+		Message:      "item reverted successfully",
+	})
+}
+
+func (h *Handler) adjustStockHandler(w http.ResponseWriter, r *http.Request) {
+	itemID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req dto.AdjustStockRequest
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errValidate := h.valid.Struct(req); errValidate != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(errValidate))
+		return
+	}
+
+	var userID *uuid.UUID
+	if id, ok := middleware.UserIDFromContext(r.Context()); ok {
+		userID = id
+	}
+
+	result, err := h.service.AdjustStock(r.Context(), itemID, req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrItemNotFound):
+			h.respondError(w, http.StatusNotFound, "item not found")
+		case errors.Is(err, apperrors.ErrInsufficientStock):
+			h.respondError(w, http.StatusConflict, "insufficient stock")
+		case errors.Is(err, apperrors.ErrWarehouseNotFound):
+			h.respondError(w, http.StatusNotFound, "warehouse not found")
+		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := converter.ItemToStockAdjustmentResponse(result, req.Delta)
+	resp.Message = "stock adjusted successfully"
+	setETag(w, result.Version)
+	h.respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/converter"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+)
+
+func (h *Handler) getItemAsOfHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	at, err := parseAsOfQuery(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	item, err := h.service.GetItemAsOf(r.Context(), id, at)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrItemNotFound):
+			h.respondError(w, http.StatusNotFound, "item did not exist at the given time")
+		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.ItemToResponse(item))
+}
+
+func (h *Handler) getItemsSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	at, err := parseAsOfQuery(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	items, err := h.service.GetItemsAsOf(r.Context(), at)
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := converter.ItemsToResponse(items)
+	h.respondJSON(w, http.StatusOK, dto.ItemsSnapshotResponse{
+		At:    at.UTC().Format(time.RFC3339),
+		Items: resp,
+		Total: len(resp),
+	})
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/converter"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/middleware"
+)
+
+func (h *Handler) createLocationHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	result, err := h.service.CreateLocation(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrWarehouseNotFound):
+			h.respondError(w, http.StatusNotFound, "warehouse not found")
+		case errors.Is(err, apperrors.ErrLocationExists):
+			h.respondError(w, http.StatusConflict, "storage location with this code already exists")
+		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, converter.LocationToResponse(result))
+}
+
+func (h *Handler) getLocationsHandler(w http.ResponseWriter, r *http.Request) {
+	var warehouseID *uuid.UUID
+	if value := strings.TrimSpace(r.URL.Query().Get("warehouse_id")); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalid warehouse_id")
+			return
+		}
+		warehouseID = &id
+	}
+
+	result, err := h.service.GetLocations(r.Context(), warehouseID)
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := converter.LocationsToResponse(result)
+	h.respondJSON(w, http.StatusOK, dto.LocationListResponse{
+		Locations: resp,
+		Total:     len(resp),
+	})
+}
+
+func (h *Handler) getLocationContentsHandler(w http.ResponseWriter, r *http.Request) {
+	locationID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	location, contents, err := h.service.GetLocationContents(r.Context(), locationID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrLocationNotFound):
+			h.respondError(w, http.StatusNotFound, "storage location not found")
+		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := converter.LocationContentsToResponse(contents)
+	h.respondJSON(w, http.StatusOK, dto.LocationContentListResponse{
+		Location: converter.LocationToResponse(location),
+		Items:    resp,
+		Total:    len(resp),
+	})
+}
+
+func (h *Handler) putawayHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.PutawayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	var userID *uuid.UUID
+	if id, ok := middleware.UserIDFromContext(r.Context()); ok {
+		userID = id
+	}
+
+	result, err := h.service.PutawayItem(r.Context(), req, userID)
+	if err != nil {
+		h.respondLocationError(w, err, "not enough unplaced stock in warehouse")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.ItemLocationsResponse{
+		ItemID:    req.ItemID,
+		Locations: converter.ItemLocationsToResponse(result),
+		Message:   "stock placed successfully",
+	})
+}
+
+func (h *Handler) moveLocationHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.MoveLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	var userID *uuid.UUID
+	if id, ok := middleware.UserIDFromContext(r.Context()); ok {
+		userID = id
+	}
+
+	result, err := h.service.MoveItemLocation(r.Context(), req, userID)
+	if err != nil {
+		h.respondLocationError(w, err, "insufficient stock in source location")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.ItemLocationsResponse{
+		ItemID:    req.ItemID,
+		Locations: converter.ItemLocationsToResponse(result),
+		Message:   "stock moved successfully",
+	})
+}
+
+func (h *Handler) respondLocationError(w http.ResponseWriter, err error, insufficientMsg string) {
+	switch {
+	case errors.Is(err, apperrors.ErrItemNotFound):
+		h.respondError(w, http.StatusNotFound, "item not found")
+	case errors.Is(err, apperrors.ErrLocationNotFound):
+		h.respondError(w, http.StatusNotFound, "storage location not found")
+	case errors.Is(err, apperrors.ErrLocationMismatch):
+		h.respondError(w, http.StatusBadRequest, "storage locations belong to different warehouses")
+	case errors.Is(err, apperrors.ErrInsufficientStock):
+		h.respondError(w, http.StatusConflict, insufficientMsg)
+	default:
+		h.responseLogger(w).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/converter"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+)
+
+func (h *Handler) validateMovementUUIDParams(req *dto.GetMovementsRequest) error {
+	if req.ItemID != nil {
+		if _, err := uuid.Parse(*req.ItemID); err != nil {
+			return fmt.Errorf("invalid item_id: %w", err)
+		}
+	}
+
+	if req.UserID != nil {
+		if _, err := uuid.Parse(*req.UserID); err != nil {
+			return fmt.Errorf("invalid user_id: %w", err)
+		}
+	}
+
+	if req.WarehouseID != nil {
+		if _, err := uuid.Parse(*req.WarehouseID); err != nil {
+			return fmt.Errorf("invalid warehouse_id: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) getMovementsHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.GetMovementsRequest
+
+	if err := parseMovementsQuery(r, &req); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	if err := h.validateMovementUUIDParams(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.service.GetMovements(r.Context(), req)
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.MovementListResponse{
+		Movements:     converter.MovementsToResponse(result.Movements),
+		Total:         result.Total,
+		TotalQuantity: result.TotalQuantity,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+		NextCursor:    result.NextCursor,
+	})
+}
+
+func (h *Handler) getItemMovementsHandler(w http.ResponseWriter, r *http.Request) {
+	itemID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req dto.GetMovementsRequest
+	if errParse := parseMovementsQuery(r, &req); errParse != nil {
+		h.respondError(w, http.StatusBadRequest, errParse.Error())
+		return
+	}
+
+	if errValidate := h.valid.Struct(req); errValidate != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(errValidate))
+		return
+	}
+
+	if errUUID := h.validateMovementUUIDParams(&req); errUUID != nil {
+		h.respondError(w, http.StatusBadRequest, errUUID.Error())
+		return
+	}
+
+	result, err := h.service.GetItemMovements(r.Context(), itemID, req)
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.MovementListResponse{
+		Movements:     converter.MovementsToResponse(result.Movements),
+		Total:         result.Total,
+		TotalQuantity: result.TotalQuantity,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+		NextCursor:    result.NextCursor,
+	})
+}
+
+func (h *Handler) getStockDiscrepanciesHandler(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.GetStockDiscrepancies(r.Context())
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := converter.DiscrepanciesToResponse(result)
+	h.respondJSON(w, http.StatusOK, dto.StockDiscrepancyListResponse{
+		Items: resp,
+		Total: len(resp),
+	})
+}
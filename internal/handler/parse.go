@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
 	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/pkg/cursor"
 )
 
 func parseUUIDParam(r *http.Request) (uuid.UUID, error) {
@@ -28,6 +31,205 @@ func parseUUIDParam(r *http.Request) (uuid.UUID, error) {
 	return id, nil
 }
 
+func parseIfMatch(r *http.Request) (*int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return nil, nil //nolint:nilnil // absent header is not an error
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	value = strings.Trim(value, `"`)
+
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return nil, errors.New("invalid If-Match header")
+	}
+
+	return &version, nil
+}
+
+func parseItemsQuery(r *http.Request, req *dto.GetItemsRequest) error {
+	const defaultItemsLimit = 50
+
+	q := r.URL.Query()
+
+	nameStr := strings.TrimSpace(q.Get("name"))
+	if nameStr != "" {
+		req.Name = &nameStr
+	}
+
+	warehouseIDStr := strings.TrimSpace(q.Get("warehouse_id"))
+	if warehouseIDStr != "" {
+		if _, err := uuid.Parse(warehouseIDStr); err != nil {
+			return errors.New("invalid warehouse_id")
+		}
+		req.WarehouseID = &warehouseIDStr
+	}
+
+	categoryIDStr := strings.TrimSpace(q.Get("category_id"))
+	if categoryIDStr != "" {
+		if _, err := uuid.Parse(categoryIDStr); err != nil {
+			return errors.New("invalid category_id")
+		}
+		req.CategoryID = &categoryIDStr
+	}
+
+	if err := parseItemTagFilters(q, req); err != nil {
+		return err
+	}
+
+	var err error
+	if req.MinQuantity, err = parseIntParam(q.Get("min_quantity"), "min_quantity"); err != nil {
+		return err
+	}
+	if req.MaxQuantity, err = parseIntParam(q.Get("max_quantity"), "max_quantity"); err != nil {
+		return err
+	}
+	if req.MinPrice, err = parseIntParam(q.Get("min_price"), "min_price"); err != nil {
+		return err
+	}
+	if req.MaxPrice, err = parseIntParam(q.Get("max_price"), "max_price"); err != nil {
+		return err
+	}
+
+	if req.MinQuantity != nil && req.MaxQuantity != nil && *req.MinQuantity > *req.MaxQuantity {
+		return errors.New("parameter 'min_quantity' cannot be greater than 'max_quantity'")
+	}
+	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice {
+		return errors.New("parameter 'min_price' cannot be greater than 'max_price'")
+	}
+
+	sortByStr := strings.TrimSpace(q.Get("sort_by"))
+	if sortByStr != "" {
+		req.SortBy = &sortByStr
+	}
+
+	sortOrderStr := strings.TrimSpace(q.Get("sort_order"))
+	if sortOrderStr != "" {
+		req.SortOrder = &sortOrderStr
+	}
+
+	req.Limit = defaultItemsLimit
+	limit, err := parseIntParam(q.Get("limit"), "limit")
+	if err != nil {
+		return err
+	}
+	if limit != nil {
+		req.Limit = *limit
+	}
+
+	offset, err := parseIntParam(q.Get("offset"), "offset")
+	if err != nil {
+		return err
+	}
+	if offset != nil {
+		req.Offset = *offset
+	}
+
+	return nil
+}
+
+func parseItemTagFilters(q url.Values, req *dto.GetItemsRequest) error {
+	for _, tag := range q["tag"] {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			req.Tags = append(req.Tags, tag)
+		}
+	}
+
+	for _, attr := range q["attr"] {
+		key, value, ok := strings.Cut(attr, ":")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return errors.New("invalid attr, expected key:value")
+		}
+		if req.Attributes == nil {
+			req.Attributes = make(map[string]string)
+		}
+		req.Attributes[key] = strings.TrimSpace(value)
+	}
+
+	return nil
+}
+
+func parseTrashItemsQuery(r *http.Request, req *dto.GetTrashItemsRequest) error {
+	page, err := parsePageQuery(r, nil, "")
+	if err != nil {
+		return err
+	}
+
+	req.Limit, req.Offset = page.limit, page.offset
+
+	return nil
+}
+
+func parseLowStockItemsQuery(r *http.Request, req *dto.GetLowStockItemsRequest) error {
+	page, err := parsePageQuery(r, nil, "")
+	if err != nil {
+		return err
+	}
+
+	req.Limit, req.Offset = page.limit, page.offset
+
+	return nil
+}
+
+func parseAlertsQuery(r *http.Request, req *dto.GetAlertsRequest) error {
+	q := r.URL.Query()
+
+	acknowledgedStr := strings.TrimSpace(q.Get("acknowledged"))
+	if acknowledgedStr != "" {
+		acknowledged, err := strconv.ParseBool(acknowledgedStr)
+		if err != nil {
+			return errors.New("invalid acknowledged, expected true or false")
+		}
+		req.Acknowledged = &acknowledged
+	}
+
+	page, err := parsePageQuery(r, nil, "")
+	if err != nil {
+		return err
+	}
+
+	req.Limit, req.Offset = page.limit, page.offset
+
+	return nil
+}
+
+func parseWebhookDeliveriesQuery(r *http.Request, req *dto.GetWebhookDeliveriesRequest) error {
+	q := r.URL.Query()
+
+	statusStr := strings.TrimSpace(q.Get("status"))
+	if statusStr != "" {
+		req.Status = &statusStr
+	}
+
+	page, err := parsePageQuery(r, nil, "")
+	if err != nil {
+		return err
+	}
+
+	req.Limit, req.Offset = page.limit, page.offset
+
+	return nil
+}
+
+func parseChangeReasonQuery(r *http.Request) dto.ChangeReason {
+	q := r.URL.Query()
+	var reason dto.ChangeReason
+
+	reasonStr := strings.TrimSpace(q.Get("reason"))
+	if reasonStr != "" {
+		reason.Reason = &reasonStr
+	}
+
+	reasonCodeStr := strings.TrimSpace(q.Get("reason_code"))
+	if reasonCodeStr != "" {
+		reason.ReasonCode = &reasonCodeStr
+	}
+
+	return reason
+}
+
 func parseHistoryQuery(r *http.Request, req *dto.GetHistoryRequest) error {
 	q := r.URL.Query()
 
@@ -41,33 +243,130 @@ func parseHistoryQuery(r *http.Request, req *dto.GetHistoryRequest) error {
 		req.UserID = &userIDStr
 	}
 
+	warehouseIDStr := strings.TrimSpace(q.Get("warehouse_id"))
+	if warehouseIDStr != "" {
+		req.WarehouseID = &warehouseIDStr
+	}
+
+	locationIDStr := strings.TrimSpace(q.Get("location_id"))
+	if locationIDStr != "" {
+		req.LocationID = &locationIDStr
+	}
+
 	actionStr := strings.TrimSpace(q.Get("action"))
 	if actionStr != "" {
 		req.Action = &actionStr
 	}
 
-	if fromStr := q.Get("from"); fromStr != "" {
-		from, err := parseDate(fromStr)
-		if err != nil && !errors.Is(err, apperrors.ErrEmptyDate) {
-			return err
-		}
-		if from != nil {
-			req.From = from
-		}
+	reasonCodeStr := strings.TrimSpace(q.Get("reason_code"))
+	if reasonCodeStr != "" {
+		req.ReasonCode = &reasonCodeStr
 	}
 
-	if toStr := q.Get("to"); toStr != "" {
-		to, err := parseDate(toStr)
-		if err != nil && !errors.Is(err, apperrors.ErrEmptyDate) {
-			return err
-		}
-		if to != nil {
-			req.To = to
+	reasonStr := strings.TrimSpace(q.Get("reason"))
+	if reasonStr != "" {
+		req.Reason = &reasonStr
+	}
+
+	requestIDStr := strings.TrimSpace(q.Get("request_id"))
+	if requestIDStr != "" {
+		req.RequestID = &requestIDStr
+	}
+
+	apiKeyIDStr := strings.TrimSpace(q.Get("api_key_id"))
+	if apiKeyIDStr != "" {
+		req.APIKeyID = &apiKeyIDStr
+	}
+
+	var err error
+	if req.From, req.To, err = parseDateRange(q.Get("from"), q.Get("to")); err != nil {
+		return err
+	}
+
+	sortByStr := strings.TrimSpace(q.Get("sort_by"))
+	if sortByStr != "" {
+		req.SortBy = &sortByStr
+	}
+
+	sortOrderStr := strings.TrimSpace(q.Get("sort_order"))
+	if sortOrderStr != "" {
+		req.SortOrder = &sortOrderStr
+	}
+
+	return nil
+}
+
+func parseIntParam(s, param string) (*int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil //nolint:nilnil // absent parameter is not an error
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", param)
+	}
+
+	return &v, nil
+}
+
+func parseVerifyHistoryQuery(r *http.Request, req *dto.VerifyHistoryRequest) error {
+	q := r.URL.Query()
+
+	headSeqStr := strings.TrimSpace(q.Get("head_seq"))
+	if headSeqStr != "" {
+		headSeq, err := strconv.ParseInt(headSeqStr, 10, 64)
+		if err != nil {
+			return errors.New("invalid head_seq")
 		}
+		req.HeadSeq = &headSeq
+	}
+
+	headHashStr := strings.ToLower(strings.TrimSpace(q.Get("head_hash")))
+	if headHashStr != "" {
+		req.HeadHash = &headHashStr
+	}
+
+	return nil
+}
+
+func parseHistoryPageQuery(r *http.Request, req *dto.GetHistoryRequest) error {
+	page, err := parsePageQuery(r, req.SortBy, "changed_at")
+	if err != nil {
+		return err
+	}
+
+	req.Limit, req.Offset, req.Cursor = page.limit, page.offset, page.cursor
+
+	return nil
+}
+
+func parseMovementsQuery(r *http.Request, req *dto.GetMovementsRequest) error {
+	q := r.URL.Query()
+
+	itemIDStr := strings.TrimSpace(q.Get("item_id"))
+	if itemIDStr != "" {
+		req.ItemID = &itemIDStr
 	}
 
-	if req.From != nil && req.To != nil && req.From.After(*req.To) {
-		return errors.New("parameter 'from' cannot be after 'to'")
+	userIDStr := strings.TrimSpace(q.Get("user_id"))
+	if userIDStr != "" {
+		req.UserID = &userIDStr
+	}
+
+	warehouseIDStr := strings.TrimSpace(q.Get("warehouse_id"))
+	if warehouseIDStr != "" {
+		req.WarehouseID = &warehouseIDStr
+	}
+
+	kindStr := strings.TrimSpace(q.Get("kind"))
+	if kindStr != "" {
+		req.Kind = &kindStr
+	}
+
+	var err error
+	if req.From, req.To, err = parseDateRange(q.Get("from"), q.Get("to")); err != nil {
+		return err
 	}
 
 	sortByStr := strings.TrimSpace(q.Get("sort_by"))
@@ -80,9 +379,113 @@ func parseHistoryQuery(r *http.Request, req *dto.GetHistoryRequest) error {
 		req.SortOrder = &sortOrderStr
 	}
 
+	page, err := parsePageQuery(r, req.SortBy, "created_at")
+	if err != nil {
+		return err
+	}
+
+	req.Limit, req.Offset, req.Cursor = page.limit, page.offset, page.cursor
+
 	return nil
 }
 
+type pageQuery struct {
+	limit  int
+	offset int
+	cursor *cursor.Cursor
+}
+
+func parsePageQuery(r *http.Request, sortBy *string, keysetColumn string) (pageQuery, error) {
+	const defaultPageLimit = 50
+
+	q := r.URL.Query()
+	page := pageQuery{limit: defaultPageLimit}
+
+	limit, err := parseIntParam(q.Get("limit"), "limit")
+	if err != nil {
+		return page, err
+	}
+	if limit != nil {
+		if *limit < 1 {
+			return page, errors.New("parameter 'limit' must be positive")
+		}
+		page.limit = *limit
+	}
+
+	offset, err := parseIntParam(q.Get("offset"), "offset")
+	if err != nil {
+		return page, err
+	}
+	if offset != nil {
+		page.offset = *offset
+	}
+
+	cursorStr := strings.TrimSpace(q.Get("cursor"))
+	if cursorStr == "" {
+		return page, nil
+	}
+
+	if keysetColumn == "" {
+		return page, errors.New("parameter 'cursor' is not supported here, use 'offset'")
+	}
+	if page.offset > 0 {
+		return page, errors.New("parameters 'cursor' and 'offset' cannot be used together")
+	}
+	if sortBy != nil && !strings.EqualFold(*sortBy, keysetColumn) {
+		return page, fmt.Errorf("parameter 'cursor' requires sort_by=%s", keysetColumn)
+	}
+
+	c, err := cursor.Decode(cursorStr)
+	if err != nil {
+		return page, errors.New("invalid cursor")
+	}
+	page.cursor = c
+
+	return page, nil
+}
+
+func parseDateRange(fromStr, toStr string) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+
+	if fromStr != "" {
+		parsed, err := parseDate(fromStr)
+		if err != nil && !errors.Is(err, apperrors.ErrEmptyDate) {
+			return nil, nil, err
+		}
+		from = parsed
+	}
+
+	if toStr != "" {
+		parsed, err := parseDate(toStr)
+		if err != nil && !errors.Is(err, apperrors.ErrEmptyDate) {
+			return nil, nil, err
+		}
+		to = parsed
+	}
+
+	if from != nil && to != nil && from.After(*to) {
+		return nil, nil, errors.New("parameter 'from' cannot be after 'to'")
+	}
+
+	return from, to, nil
+}
+
+func parseAsOfQuery(r *http.Request) (time.Time, error) {
+	at, err := parseDate(r.URL.Query().Get("at"))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrEmptyDate) {
+			return time.Time{}, errors.New("parameter 'at' is required")
+		}
+		return time.Time{}, errors.New("parameter 'at' must be in RFC3339 format")
+	}
+
+	if at.After(time.Now()) {
+		return time.Time{}, errors.New("parameter 'at' cannot be in the future")
+	}
+
+	return *at, nil
+}
+
 func parseDate(s string) (*time.Time, error) {
 	if s == "" {
 		return nil, apperrors.ErrEmptyDate
@@ -3,8 +3,11 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"github.com/gookit/slog"
 	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/pkg/requestid"
 )
 
 func (h *Handler) respondJSON(w http.ResponseWriter, status int, data any) {
@@ -12,7 +15,7 @@ func (h *Handler) respondJSON(w http.ResponseWriter, status int, data any) {
 	w.WriteHeader(status)
 	err := json.NewEncoder(w).Encode(data)
 	if err != nil {
-		h.log.Errorf("respondJSON: %v", err.Error())
+		h.responseLogger(w).Errorf("respondJSON: %v", err.Error())
 		return
 	}
 }
@@ -22,18 +25,26 @@ func (h *Handler) respondError(w http.ResponseWriter, status int, message string
 	w.WriteHeader(status)
 	err := json.NewEncoder(w).Encode(models.Error{Error: message})
 	if err != nil {
-		h.log.Errorf("respondError: %v", err.Error())
+		h.responseLogger(w).Errorf("respondError: %v", err.Error())
 		return
 	}
 }
 
+func (h *Handler) responseLogger(w http.ResponseWriter) *slog.Record {
+	return h.log.WithExtra(slog.M{"request_id": w.Header().Get(requestid.Header)})
+}
+
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", `"`+strconv.Itoa(version)+`"`)
+}
+
 func (h *Handler) respondCSV(w http.ResponseWriter, status int, data []byte) {
 	w.Header().Set("Content-Type", "text/csv")
 	w.Header().Set("Content-Disposition", "attachment; filename=history.csv")
 	w.WriteHeader(status)
 	_, err := w.Write(data)
 	if err != nil {
-		h.log.Errorf("respondCSV: %v", err.Error())
+		h.responseLogger(w).Errorf("respondCSV: %v", err.Error())
 		return
 	}
 }
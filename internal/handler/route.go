@@ -7,29 +7,115 @@ import (
 )
 
 func (h *Handler) registerPublicRoutes(r chi.Router) {
-	r.Post("/api/login", h.SignInOrSignUp)
+	r.Post("/api/login", h.loginHandler)
+	r.Post("/api/register", h.registerHandler)
+	r.Post("/api/token/refresh", h.refreshTokenHandler)
+	r.Get("/.well-known/jwks.json", h.jwksHandler)
 }
 
 func (h *Handler) registerAPIRoutes(r chi.Router) {
 	r.Route("/api", func(r chi.Router) {
-		r.Use(middleware.AuthMiddleware(h.tokenValidator))
+		r.Use(middleware.AuthMiddleware(h.tokenValidator, h.service))
+
+		r.Post("/logout", h.logoutHandler)
 
 		r.Route("/items", func(r chi.Router) {
 			r.With(middleware.RequireRole(jwt.RoleAdmin, jwt.RoleManager)).Group(func(r chi.Router) {
 				r.Post("/", h.createItemHandler)
 				r.Put("/{id}", h.updateItemHandler)
+				r.Post("/{id}/adjust", h.adjustStockHandler)
 			})
 
-			r.With(middleware.RequireRole(jwt.RoleAdmin)).Delete("/{id}", h.deleteItemHandler)
+			r.With(middleware.RequireRole(jwt.RoleAdmin)).Group(func(r chi.Router) {
+				r.Delete("/{id}", h.deleteItemHandler)
+				r.Post("/{id}/restore", h.restoreItemHandler)
+				r.Post("/{id}/revert", h.revertItemHandler)
+			})
 
 			r.Get("/", h.getItemsHandler)
+			r.Get("/trash", h.getTrashItemsHandler)
+			r.Get("/low-stock", h.getLowStockItemsHandler)
+			r.Get("/snapshot", h.getItemsSnapshotHandler)
+			r.Get("/by-sku/{sku}", h.getItemBySKUHandler)
+			r.Get("/by-barcode/{code}", h.getItemByBarcodeHandler)
 			r.Get("/{id}", h.getItemByIDHandler)
 			r.Get("/{id}/history", h.getItemHistoryHandler)
+			r.Get("/{id}/as-of", h.getItemAsOfHandler)
+			r.Get("/{id}/movements", h.getItemMovementsHandler)
+		})
+
+		r.Route("/categories", func(r chi.Router) {
+			r.With(middleware.RequireRole(jwt.RoleAdmin, jwt.RoleManager)).Group(func(r chi.Router) {
+				r.Post("/", h.createCategoryHandler)
+				r.Put("/{id}", h.updateCategoryHandler)
+				r.Delete("/{id}", h.deleteCategoryHandler)
+			})
+
+			r.Get("/", h.getCategoriesHandler)
+			r.Get("/{id}", h.getCategoryByIDHandler)
+		})
+
+		r.Route("/warehouses", func(r chi.Router) {
+			r.With(middleware.RequireRole(jwt.RoleAdmin)).Post("/", h.createWarehouseHandler)
+			r.Get("/", h.getWarehousesHandler)
+			r.Get("/{id}", h.getWarehouseByIDHandler)
+		})
+
+		r.With(middleware.RequireRole(jwt.RoleAdmin, jwt.RoleManager)).Post("/transfers", h.createTransferHandler)
+
+		r.Route("/locations", func(r chi.Router) {
+			r.With(middleware.RequireRole(jwt.RoleAdmin, jwt.RoleManager)).Group(func(r chi.Router) {
+				r.Post("/putaway", h.putawayHandler)
+				r.Post("/move", h.moveLocationHandler)
+			})
+
+			r.With(middleware.RequireRole(jwt.RoleAdmin)).Post("/", h.createLocationHandler)
+			r.Get("/", h.getLocationsHandler)
+			r.Get("/{id}/items", h.getLocationContentsHandler)
+		})
+
+		r.With(middleware.RequireRole(jwt.RoleAdmin)).Route("/webhooks", func(r chi.Router) {
+			r.Post("/", h.createWebhookHandler)
+			r.Get("/", h.getWebhooksHandler)
+			r.Get("/{id}", h.getWebhookByIDHandler)
+			r.Put("/{id}", h.updateWebhookHandler)
+			r.Delete("/{id}", h.deleteWebhookHandler)
+			r.Get("/{id}/deliveries", h.getWebhookDeliveriesHandler)
+		})
+
+		r.Route("/alerts", func(r chi.Router) {
+			r.Get("/", h.getAlertsHandler)
+			r.With(middleware.RequireRole(jwt.RoleAdmin, jwt.RoleManager)).Post("/{id}/acknowledge", h.acknowledgeAlertHandler)
+		})
+
+		r.Route("/movements", func(r chi.Router) {
+			r.Get("/", h.getMovementsHandler)
+			r.With(middleware.RequireRole(jwt.RoleAdmin)).Get("/reconcile", h.getStockDiscrepanciesHandler)
+		})
+
+		r.Route("/users", func(r chi.Router) {
+			r.Use(middleware.RequireRole(jwt.RoleAdmin))
+			r.Get("/", h.getUsersHandler)
+			r.Post("/", h.createUserHandler)
+			r.Get("/{id}", h.getUserHandler)
+			r.Put("/{id}", h.updateUserHandler)
+			r.Delete("/{id}", h.deleteUserHandler)
+			r.Get("/{id}/history", h.getUserHistoryHandler)
+		})
+
+		r.Route("/api-keys", func(r chi.Router) {
+			r.Use(middleware.RequireRole(jwt.RoleAdmin))
+			r.Get("/", h.getAPIKeysHandler)
+			r.Post("/", h.createAPIKeyHandler)
+			r.Get("/{id}", h.getAPIKeyHandler)
+			r.Delete("/{id}", h.revokeAPIKeyHandler)
 		})
 
 		r.Route("/history", func(r chi.Router) {
 			r.Get("/", h.getHistoryHandler)
 			r.Get("/export", h.exportHistoryHandler)
+			r.Get("/stream", h.streamHistoryHandler)
+			r.With(middleware.RequireRole(jwt.RoleAdmin)).Get("/verify", h.verifyHistoryHandler)
 		})
 	})
 }
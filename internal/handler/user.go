@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/converter"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/middleware"
+)
+
+func (h *Handler) createUserHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateUserRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	var actorID *uuid.UUID
+	if id, ok := middleware.UserIDFromContext(r.Context()); ok {
+		actorID = id
+	}
+
+	result, err := h.service.CreateUser(r.Context(), req, actorID)
+	if err != nil {
+		h.respondUserError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, converter.UserToResponse(result))
+}
+
+func (h *Handler) getUsersHandler(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.GetUsers(r.Context())
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := converter.UsersToResponse(result)
+	h.respondJSON(w, http.StatusOK, dto.UserListResponse{
+		Users: resp,
+		Total: len(resp),
+	})
+}
+
+func (h *Handler) getUserHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.service.GetUserByID(r.Context(), id)
+	if err != nil {
+		h.respondUserError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.UserToResponse(result))
+}
+
+func (h *Handler) updateUserHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req dto.UpdateUserRequest
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errValidate := h.valid.Struct(req); errValidate != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(errValidate))
+		return
+	}
+
+	if req.Role == nil && req.Password == nil && req.Disabled == nil {
+		h.respondError(w, http.StatusBadRequest, "at least one of role, password or disabled is required")
+		return
+	}
+
+	var actorID *uuid.UUID
+	if userID, ok := middleware.UserIDFromContext(r.Context()); ok {
+		actorID = userID
+	}
+
+	result, err := h.service.UpdateUser(r.Context(), id, req, actorID)
+	if err != nil {
+		h.respondUserError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.UserToResponse(result))
+}
+
+func (h *Handler) deleteUserHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reason := parseChangeReasonQuery(r)
+	if errValidate := h.valid.Struct(reason); errValidate != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(errValidate))
+		return
+	}
+
+	var actorID *uuid.UUID
+	if userID, ok := middleware.UserIDFromContext(r.Context()); ok {
+		actorID = userID
+	}
+
+	if err = h.service.DeleteUser(r.Context(), id, reason, actorID); err != nil {
+		h.respondUserError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]string{"message": "user deleted successfully"})
+}
+
+func (h *Handler) getUserHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.service.GetUserHistory(r.Context(), id)
+	if err != nil {
+		h.respondUserError(w, err)
+		return
+	}
+
+	resp := converter.UserHistoryToResponse(result)
+	h.respondJSON(w, http.StatusOK, dto.UserHistoryListResponse{
+		History: resp,
+		Total:   len(resp),
+	})
+}
+
+func (h *Handler) respondUserError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrUserNotFound):
+		h.respondError(w, http.StatusNotFound, "user not found")
+	case errors.Is(err, apperrors.ErrUserAlreadyExists):
+		h.respondError(w, http.StatusConflict, "user already exists")
+	case errors.Is(err, apperrors.ErrSelfLockout):
+		h.respondError(w, http.StatusConflict, "administrators cannot demote, disable or delete themselves")
+	case errors.Is(err, apperrors.ErrLastAdmin):
+		h.respondError(w, http.StatusConflict, "cannot demote, disable or delete the last active administrator")
+	case errors.Is(err, apperrors.ErrPasswordTooLong):
+		h.respondError(w, http.StatusBadRequest, "password must not exceed 72 bytes")
+	default:
+		h.responseLogger(w).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
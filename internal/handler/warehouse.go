@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/converter"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/middleware"
+)
+
+func (h *Handler) createWarehouseHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateWarehouseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	result, err := h.service.CreateWarehouse(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrWarehouseExists):
+			h.respondError(w, http.StatusConflict, "warehouse with this code already exists")
+		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, converter.WarehouseToResponse(result))
+}
+
+func (h *Handler) getWarehousesHandler(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.GetWarehouses(r.Context())
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := converter.WarehousesToResponse(result)
+	h.respondJSON(w, http.StatusOK, dto.WarehouseListResponse{
+		Warehouses: resp,
+		Total:      len(resp),
+	})
+}
+
+func (h *Handler) getWarehouseByIDHandler(w http.ResponseWriter, r *http.Request) {
+	warehouseID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.service.GetWarehouseByID(r.Context(), warehouseID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrWarehouseNotFound):
+			h.respondError(w, http.StatusNotFound, "warehouse not found")
+		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.WarehouseToResponse(result))
+}
+
+func (h *Handler) createTransferHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.TransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	var userID *uuid.UUID
+	if id, ok := middleware.UserIDFromContext(r.Context()); ok {
+		userID = id
+	}
+
+	result, err := h.service.TransferStock(r.Context(), req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrItemNotFound):
+			h.respondError(w, http.StatusNotFound, "item not found")
+		case errors.Is(err, apperrors.ErrWarehouseNotFound):
+			h.respondError(w, http.StatusNotFound, "warehouse not found")
+		case errors.Is(err, apperrors.ErrInsufficientStock):
+			h.respondError(w, http.StatusConflict, "insufficient stock in source warehouse")
+		default:
+			h.logger(r.Context()).Errorf("Service error: %v", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := converter.TransferToResponse(result)
+	resp.Message = "stock transferred successfully"
+	h.respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/converter"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+)
+
+func (h *Handler) createWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	result, err := h.service.CreateWebhook(r.Context(), req)
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := converter.WebhookToResponse(result)
+	resp.Secret = result.Secret
+	h.respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *Handler) getWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.GetWebhooks(r.Context())
+	if err != nil {
+		h.logger(r.Context()).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := converter.WebhooksToResponse(result)
+	h.respondJSON(w, http.StatusOK, dto.WebhookListResponse{
+		Webhooks: resp,
+		Total:    len(resp),
+	})
+}
+
+func (h *Handler) getWebhookByIDHandler(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.service.GetWebhookByID(r.Context(), webhookID)
+	if err != nil {
+		h.respondWebhookError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.WebhookToResponse(result))
+}
+
+func (h *Handler) updateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errValidate := h.valid.Struct(req); errValidate != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(errValidate))
+		return
+	}
+
+	result, err := h.service.UpdateWebhook(r.Context(), webhookID, req)
+	if err != nil {
+		h.respondWebhookError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, converter.WebhookToResponse(result))
+}
+
+func (h *Handler) deleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err = h.service.DeleteWebhook(r.Context(), webhookID); err != nil {
+		h.respondWebhookError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]string{"message": "webhook deleted successfully"})
+}
+
+func (h *Handler) getWebhookDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := parseUUIDParam(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req dto.GetWebhookDeliveriesRequest
+	if err = parseWebhookDeliveriesQuery(r, &req); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err = h.valid.Struct(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, h.valid.FormatValidationError(err))
+		return
+	}
+
+	result, err := h.service.GetWebhookDeliveries(r.Context(), webhookID, req)
+	if err != nil {
+		h.respondWebhookError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, dto.WebhookDeliveryListResponse{
+		Deliveries: converter.WebhookDeliveriesToResponse(result.Deliveries),
+		Total:      result.Total,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	})
+}
+
+func (h *Handler) respondWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrWebhookNotFound):
+		h.respondError(w, http.StatusNotFound, "webhook subscription not found")
+	default:
+		h.responseLogger(w).Errorf("Service error: %v", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
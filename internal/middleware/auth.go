@@ -3,13 +3,16 @@ package middleware
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"slices"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/gookit/slog"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
 	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/pkg/apikey"
 	"github.com/kstsm/wb-warehouse-control/pkg/jwt"
 )
 
@@ -18,11 +21,35 @@ type contextKey string
 const (
 	userIDContextKey contextKey = "user_id"
 	roleContextKey   contextKey = "role"
+	claimsContextKey contextKey = "claims"
 )
 
-func AuthMiddleware(tokenValidator jwt.TokenValidator) func(http.Handler) http.Handler {
+type SessionChecker interface {
+	GetActiveUser(ctx context.Context, id uuid.UUID) (*models.User, error)
+	IsTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error)
+	AuthenticateAPIKey(ctx context.Context, raw string) (*models.APIKey, error)
+}
+
+func AuthMiddleware(tokenValidator jwt.TokenValidator, sessions SessionChecker) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if rawKey := strings.TrimSpace(r.Header.Get(apikey.Header)); rawKey != "" {
+				key, err := sessions.AuthenticateAPIKey(r.Context(), rawKey)
+				if err != nil {
+					if !errors.Is(err, apperrors.ErrInvalidAPIKey) {
+						slog.Errorf("AuthMiddleware: %v", err)
+					}
+					respondError(w)
+					return
+				}
+
+				ctx := apikey.NewContext(r.Context(), key.ID)
+				ctx = context.WithValue(ctx, roleContextKey, jwt.Role(key.Role))
+
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			token, ok := extractBearerToken(r)
 			if !ok {
 				respondError(w)
@@ -35,8 +62,33 @@ func AuthMiddleware(tokenValidator jwt.TokenValidator) func(http.Handler) http.H
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), userIDContextKey, claims.UserID)
-			ctx = context.WithValue(ctx, roleContextKey, claims.Role)
+			jti, err := uuid.Parse(claims.ID)
+			if err != nil {
+				respondError(w)
+				return
+			}
+
+			revoked, err := sessions.IsTokenRevoked(r.Context(), jti)
+			if err != nil || revoked {
+				if err != nil {
+					slog.Errorf("AuthMiddleware: %v", err)
+				}
+				respondError(w)
+				return
+			}
+
+			user, err := sessions.GetActiveUser(r.Context(), claims.UserID)
+			if err != nil {
+				if !errors.Is(err, apperrors.ErrUserNotFound) && !errors.Is(err, apperrors.ErrUserDisabled) {
+					slog.Errorf("AuthMiddleware: %v", err)
+				}
+				respondError(w)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userIDContextKey, user.ID)
+			ctx = context.WithValue(ctx, roleContextKey, jwt.Role(user.Role))
+			ctx = context.WithValue(ctx, claimsContextKey, claims)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -67,6 +119,11 @@ func RoleFromContext(ctx context.Context) (jwt.Role, bool) {
 	return role, ok
 }
 
+func ClaimsFromContext(ctx context.Context) (*jwt.Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(*jwt.Claims)
+	return claims, ok
+}
+
 func UserIDFromContext(ctx context.Context) (*uuid.UUID, bool) {
 	userID, ok := ctx.Value(userIDContextKey).(uuid.UUID)
 	if !ok {
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gookit/slog"
+	"github.com/kstsm/wb-warehouse-control/pkg/requestid"
+)
+
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSpace(r.Header.Get(requestid.Header))
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+
+		w.Header().Set(requestid.Header, id)
+
+		next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
+
+func RequestIDLogProcessor() slog.Processor {
+	return slog.ProcessorFunc(func(record *slog.Record) {
+		if record.Ctx == nil {
+			return
+		}
+
+		if id, ok := requestid.FromContext(record.Ctx); ok {
+			record.SetExtraValue("request_id", id)
+		}
+	})
+}
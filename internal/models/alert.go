@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Alert struct {
+	ID                   uuid.UUID
+	ItemID               uuid.UUID
+	ItemSKU              string
+	ItemName             string
+	Kind                 string
+	Quantity             int
+	ReorderPoint         int
+	CreatedAt            time.Time
+	AcknowledgedAt       *time.Time
+	AcknowledgedBy       *uuid.UUID
+	APIKeyID             *uuid.UUID
+	AcknowledgedAPIKeyID *uuid.UUID
+}
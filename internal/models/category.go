@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Category struct {
+	ID        uuid.UUID
+	ParentID  *uuid.UUID
+	Name      string
+	Path      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
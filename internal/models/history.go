@@ -7,11 +7,26 @@ import (
 )
 
 type History struct {
-	ID        uuid.UUID
-	ItemID    uuid.UUID
-	Action    string
-	UserID    *uuid.UUID
-	ChangedAt time.Time
-	OldData   map[string]any
-	NewData   map[string]any
+	ID            uuid.UUID
+	ItemID        uuid.UUID
+	Action        string
+	UserID        *uuid.UUID
+	ChangedAt     time.Time
+	OldData       map[string]any
+	NewData       map[string]any
+	QuantityDelta *int
+	ReasonCode    *string
+	Reference     *string
+	WarehouseID   *uuid.UUID
+	LocationID    *uuid.UUID
+	RevertedFrom  *uuid.UUID
+	Reason        *string
+	RequestID     *string
+	APIKeyID      *uuid.UUID
+}
+
+type HistoryPage struct {
+	Histories  []*History
+	Total      int
+	NextCursor *string
 }
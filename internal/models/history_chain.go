@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	HistoryChainHashMismatch     = "hash_mismatch"
+	HistoryChainPrevHashMismatch = "prev_hash_mismatch"
+	HistoryChainAnchorMismatch   = "anchor_mismatch"
+	HistoryChainAnchorMissing    = "anchor_missing"
+)
+
+type HistoryChainLink struct {
+	ChainSeq      int64
+	ID            uuid.UUID
+	ItemID        uuid.UUID
+	Action        string
+	UserID        *uuid.UUID
+	ChangedAt     time.Time
+	OldData       *string
+	NewData       *string
+	QuantityDelta *int
+	ReasonCode    *string
+	Reference     *string
+	WarehouseID   *uuid.UUID
+	LocationID    *uuid.UUID
+	RevertedFrom  *uuid.UUID
+	Reason        *string
+	RequestID     *string
+	APIKeyID      *uuid.UUID
+	PrevHash      *string
+	Hash          string
+}
+
+type HistoryChainAnchor struct {
+	ChainSeq int64
+	Hash     string
+}
+
+type HistoryChainBreak struct {
+	HistoryID uuid.UUID
+	ChainSeq  int64
+	ChangedAt time.Time
+	Reason    string
+}
+
+type HistoryVerification struct {
+	Verified int
+	HeadSeq  *int64
+	HeadHash *string
+	Broken   *HistoryChainBreak
+}
@@ -7,11 +7,21 @@ import (
 )
 
 type Item struct {
-	ID          uuid.UUID
-	Name        string
-	Description string
-	Quantity    int
-	Price       int
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           uuid.UUID
+	SKU          string
+	Barcodes     []string
+	CategoryID   *uuid.UUID
+	Name         string
+	Description  string
+	Attributes   map[string]any
+	Tags         []string
+	ReorderPoint *int
+	Quantity     int
+	Price        int
+	Version      int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    *time.Time
+	Stocks       []ItemStock
+	Locations    []ItemLocation
 }
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StorageLocation struct {
+	ID          uuid.UUID
+	WarehouseID uuid.UUID
+	Zone        string
+	Aisle       string
+	Rack        string
+	Bin         string
+	Code        string
+	CreatedAt   time.Time
+}
+
+type ItemLocation struct {
+	LocationID   uuid.UUID
+	LocationCode string
+	WarehouseID  uuid.UUID
+	Quantity     int
+}
+
+type LocationContent struct {
+	ItemID   uuid.UUID
+	Name     string
+	Quantity int
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockMovement struct {
+	ID          uuid.UUID
+	ItemID      uuid.UUID
+	WarehouseID *uuid.UUID
+	Kind        string
+	Quantity    int
+	Reference   *string
+	UserID      *uuid.UUID
+	CreatedAt   time.Time
+}
+
+type StockMovementPage struct {
+	Movements     []*StockMovement
+	Total         int
+	TotalQuantity int
+	NextCursor    *string
+}
+
+type StockDiscrepancy struct {
+	ItemID         uuid.UUID
+	Name           string
+	Quantity       int
+	LedgerQuantity int
+}
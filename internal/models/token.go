@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RefreshToken struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	FamilyID   uuid.UUID
+	TokenHash  string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+	ReplacedBy *uuid.UUID
+}
+
+type AuthTokens struct {
+	AccessToken  string
+	ExpiresIn    time.Duration
+	RefreshToken string
+	Role         string
+}
+
+type APIKey struct {
+	ID         uuid.UUID
+	Name       string
+	Role       string
+	KeyPrefix  string
+	KeyHash    string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedBy  *uuid.UUID
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
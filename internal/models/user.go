@@ -6,10 +6,42 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	UserActionCreate        = "create"
+	UserActionRoleChange    = "role_change"
+	UserActionDisable       = "disable"
+	UserActionEnable        = "enable"
+	UserActionPasswordReset = "password_reset"
+	UserActionDelete        = "delete"
+)
+
 type User struct {
-	ID        uuid.UUID
-	Name      string
-	Role      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           uuid.UUID
+	Name         string
+	Role         string
+	PasswordHash *string
+	DisabledAt   *time.Time
+	DeletedAt    *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type UserUpdate struct {
+	Role         *string
+	PasswordHash *string
+	Disabled     *bool
+}
+
+type UserHistory struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Action     string
+	ChangedBy  *uuid.UUID
+	ChangedAt  time.Time
+	OldData    map[string]any
+	NewData    map[string]any
+	ReasonCode *string
+	Reason     *string
+	RequestID  *string
+	APIKeyID   *uuid.UUID
 }
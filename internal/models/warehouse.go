@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Warehouse struct {
+	ID        uuid.UUID
+	Code      string
+	Name      string
+	Address   *string
+	IsDefault bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ItemStock struct {
+	WarehouseID   uuid.UUID
+	WarehouseCode string
+	WarehouseName string
+	Quantity      int
+}
+
+type Transfer struct {
+	ItemID          uuid.UUID
+	FromWarehouseID uuid.UUID
+	ToWarehouseID   uuid.UUID
+	Quantity        int
+	Stocks          []ItemStock
+}
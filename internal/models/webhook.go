@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookEventItemCreated = "item.created"
+	WebhookEventItemUpdated = "item.updated"
+	WebhookEventItemDeleted = "item.deleted"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+type WebhookSubscription struct {
+	ID        uuid.UUID
+	URL       string
+	Events    []string
+	Secret    string
+	IsActive  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	ItemID         uuid.UUID
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	ResponseStatus *int
+	LastError      *string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+type WebhookDeliveryPage struct {
+	Deliveries []*WebhookDelivery
+	Total      int
+}
+
+type PendingWebhookDelivery struct {
+	ID         uuid.UUID
+	Attempts   int
+	URL        string
+	Secret     string
+	EventID    uuid.UUID
+	EventType  string
+	ItemID     uuid.UUID
+	UserID     *uuid.UUID
+	APIKeyID   *uuid.UUID
+	Payload    []byte
+	OccurredAt time.Time
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+	"github.com/kstsm/wb-warehouse-control/pkg/apikey"
+)
+
+func (r *Repository) GetAlerts(ctx context.Context, req dto.GetAlertsRequest) ([]*models.Alert, int, error) {
+	var total int
+	if err := r.conn.QueryRow(ctx, queries.GetAlertsCountQuery, req.Acknowledged).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("QueryRow-GetAlertsCount: %w", err)
+	}
+
+	rows, err := r.conn.Query(ctx, queries.GetAlertsQuery, req.Acknowledged, req.Limit, req.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Query-GetAlerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := make([]*models.Alert, 0)
+	for rows.Next() {
+		alert, errScan := r.scanAlert(rows)
+		if errScan != nil {
+			return nil, 0, fmt.Errorf("Scan-GetAlerts: %w", errScan)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, 0, fmt.Errorf("GetAlerts rows.Err: %w", errRows)
+	}
+
+	return alerts, total, nil
+}
+
+func (r *Repository) AcknowledgeAlert(ctx context.Context, id uuid.UUID, userID *uuid.UUID) (*models.Alert, error) {
+	var apiKeyID *uuid.UUID
+	if keyID, ok := apikey.FromContext(ctx); ok {
+		apiKeyID = &keyID
+	}
+
+	alert, err := r.scanAlert(r.conn.QueryRow(ctx, queries.AcknowledgeAlertQuery, id, userID, apiKeyID))
+	if err == nil {
+		return alert, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("QueryRow-AcknowledgeAlert: %w", err)
+	}
+
+	var exists bool
+	if err = r.conn.QueryRow(ctx, queries.AlertExistsQuery, id).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("QueryRow-AlertExists: %w", err)
+	}
+	if !exists {
+		return nil, apperrors.ErrAlertNotFound
+	}
+
+	return nil, apperrors.ErrAlertAcknowledged
+}
+
+func (r *Repository) scanAlert(row pgx.Row) (*models.Alert, error) {
+	alert := new(models.Alert)
+	if err := row.Scan(
+		&alert.ID,
+		&alert.ItemID,
+		&alert.ItemSKU,
+		&alert.ItemName,
+		&alert.Kind,
+		&alert.Quantity,
+		&alert.ReorderPoint,
+		&alert.CreatedAt,
+		&alert.AcknowledgedAt,
+		&alert.AcknowledgedBy,
+		&alert.APIKeyID,
+		&alert.AcknowledgedAPIKeyID,
+	); err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
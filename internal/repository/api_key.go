@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+)
+
+func (r *Repository) CreateAPIKey(ctx context.Context, key models.APIKey) (*models.APIKey, error) {
+	created, err := r.scanAPIKey(r.conn.QueryRow(ctx, queries.CreateAPIKeyQuery,
+		key.ID,
+		key.Name,
+		key.Role,
+		key.KeyPrefix,
+		key.KeyHash,
+		key.ExpiresAt,
+		key.CreatedBy,
+		key.CreatedAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("QueryRow-CreateAPIKey: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *Repository) GetAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	rows, err := r.conn.Query(ctx, queries.GetAPIKeysQuery)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetAPIKeys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*models.APIKey
+	for rows.Next() {
+		key, errScan := r.scanAPIKey(rows)
+		if errScan != nil {
+			return nil, fmt.Errorf("Scan-GetAPIKeys: %w", errScan)
+		}
+		keys = append(keys, key)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetAPIKeys rows.Err: %w", errRows)
+	}
+
+	return keys, nil
+}
+
+func (r *Repository) GetAPIKeyByID(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	key, err := r.scanAPIKey(r.conn.QueryRow(ctx, queries.GetAPIKeyByIDQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-GetAPIKeyByID: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *Repository) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	key, err := r.scanAPIKey(r.conn.QueryRow(ctx, queries.RevokeAPIKeyQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-RevokeAPIKey: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *Repository) TouchAPIKey(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	key, err := r.scanAPIKey(r.conn.QueryRow(ctx, queries.TouchAPIKeyQuery, keyHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("QueryRow-TouchAPIKey: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *Repository) scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	key := new(models.APIKey)
+	if err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Role,
+		&key.KeyPrefix,
+		&key.KeyHash,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.CreatedBy,
+		&key.CreatedAt,
+		&key.RevokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
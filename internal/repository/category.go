@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/gookit/slog"
+	"github.com/jackc/pgx/v5"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+)
+
+func (r *Repository) CreateCategory(ctx context.Context, category models.Category) (*models.Category, error) {
+	if _, err := r.conn.Exec(ctx, queries.CreateCategoryQuery,
+		category.ID,
+		category.ParentID,
+		category.Name,
+		category.CreatedAt,
+		category.UpdatedAt,
+	); err != nil {
+		switch {
+		case isForeignKeyViolation(err):
+			return nil, apperrors.ErrParentNotFound
+		case isUniqueViolation(err):
+			return nil, apperrors.ErrCategoryExists
+		}
+		return nil, fmt.Errorf("Exec-CreateCategory: %w", err)
+	}
+
+	return r.GetCategoryByID(ctx, category.ID)
+}
+
+func (r *Repository) GetCategories(ctx context.Context) ([]*models.Category, error) {
+	rows, err := r.conn.Query(ctx, fmt.Sprintf(queries.GetCategoriesQuery, ""))
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetCategories: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*models.Category
+	for rows.Next() {
+		category, errScan := r.scanCategory(rows)
+		if errScan != nil {
+			return nil, fmt.Errorf("Scan-GetCategories: %w", errScan)
+		}
+		categories = append(categories, category)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetCategories rows.Err: %w", errRows)
+	}
+
+	return categories, nil
+}
+
+func (r *Repository) GetCategoryByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	query := fmt.Sprintf(queries.GetCategoriesQuery, "WHERE id = $1")
+	category, err := r.scanCategory(r.conn.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-GetCategoryByID: %w", err)
+	}
+
+	return category, nil
+}
+
+func (r *Repository) UpdateCategory(
+	ctx context.Context,
+	id uuid.UUID,
+	req dto.UpdateCategoryRequest,
+) (*models.Category, error) {
+	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("BeginTx-UpdateCategory: %w", err)
+	}
+
+	defer func() {
+		rbErr := tx.Rollback(context.Background())
+		if rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			slog.Errorf("Rollback-UpdateCategory: %v", rbErr)
+		}
+	}()
+
+	if req.ParentID != nil {
+		if _, err = tx.Exec(ctx, queries.LockCategoryMoveQuery, id, *req.ParentID); err != nil {
+			return nil, fmt.Errorf("Exec-UpdateCategory: %w", err)
+		}
+
+		var isDescendant bool
+		if err = tx.QueryRow(ctx, queries.IsCategoryDescendantQuery, id, *req.ParentID).Scan(&isDescendant); err != nil {
+			return nil, fmt.Errorf("QueryRow-UpdateCategory: %w", err)
+		}
+		if isDescendant {
+			return nil, apperrors.ErrCategoryCycle
+		}
+	}
+
+	changeParent := req.ParentID != nil || req.MoveToRoot
+
+	var updatedID uuid.UUID
+	if err = tx.QueryRow(ctx, queries.UpdateCategoryQuery, id, req.Name, req.ParentID, changeParent).
+		Scan(&updatedID); err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, apperrors.ErrCategoryNotFound
+		case isForeignKeyViolation(err):
+			return nil, apperrors.ErrParentNotFound
+		case isUniqueViolation(err):
+			return nil, apperrors.ErrCategoryExists
+		}
+		return nil, fmt.Errorf("QueryRow-UpdateCategory: %w", err)
+	}
+
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, fmt.Errorf("Commit-UpdateCategory: %w", err)
+	}
+
+	return r.GetCategoryByID(ctx, id)
+}
+
+func (r *Repository) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	var deletedID uuid.UUID
+	if err := r.conn.QueryRow(ctx, queries.DeleteCategoryQuery, id).Scan(&deletedID); err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return apperrors.ErrCategoryNotFound
+		case isForeignKeyViolation(err):
+			return apperrors.ErrCategoryInUse
+		}
+		return fmt.Errorf("QueryRow-DeleteCategory: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) scanCategory(row pgx.Row) (*models.Category, error) {
+	category := new(models.Category)
+	if err := row.Scan(
+		&category.ID,
+		&category.ParentID,
+		&category.Name,
+		&category.Path,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+)
+
+func (r *Repository) GetHistoryChain(ctx context.Context, afterSeq int64, limit int) ([]*models.HistoryChainLink, error) {
+	rows, err := r.conn.Query(ctx, queries.GetHistoryChainQuery, afterSeq, limit)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetHistoryChain: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*models.HistoryChainLink
+	for rows.Next() {
+		link := new(models.HistoryChainLink)
+		if errScan := rows.Scan(
+			&link.ChainSeq,
+			&link.ID,
+			&link.ItemID,
+			&link.Action,
+			&link.UserID,
+			&link.ChangedAt,
+			&link.OldData,
+			&link.NewData,
+			&link.QuantityDelta,
+			&link.ReasonCode,
+			&link.Reference,
+			&link.WarehouseID,
+			&link.LocationID,
+			&link.RevertedFrom,
+			&link.Reason,
+			&link.RequestID,
+			&link.APIKeyID,
+			&link.PrevHash,
+			&link.Hash,
+		); errScan != nil {
+			return nil, fmt.Errorf("Scan-GetHistoryChain: %w", errScan)
+		}
+		links = append(links, link)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetHistoryChain rows.Err: %w", errRows)
+	}
+
+	return links, nil
+}
+
+func (r *Repository) GetLatestHistoryCheckpoint(ctx context.Context) (*models.HistoryChainAnchor, error) {
+	checkpoint := new(models.HistoryChainAnchor)
+	err := r.conn.QueryRow(ctx, queries.GetLatestHistoryCheckpointQuery).Scan(&checkpoint.ChainSeq, &checkpoint.Hash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil //nolint:nilnil // no checkpoint before the first verification
+		}
+		return nil, fmt.Errorf("QueryRow-GetLatestHistoryCheckpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+func (r *Repository) SaveHistoryCheckpoint(ctx context.Context, checkpoint models.HistoryChainAnchor) error {
+	if _, err := r.conn.Exec(ctx, queries.SaveHistoryCheckpointQuery, checkpoint.ChainSeq, checkpoint.Hash); err != nil {
+		return fmt.Errorf("Exec-SaveHistoryCheckpoint: %w", err)
+	}
+
+	return nil
+}
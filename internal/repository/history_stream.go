@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+)
+
+const historyChannel = "items_history"
+
+func (r *Repository) ListenHistory(ctx context.Context, onChange func(historyID uuid.UUID)) error {
+	pooled, err := r.conn.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("Acquire-ListenHistory: %w", err)
+	}
+
+	conn := pooled.Hijack()
+	defer func() {
+		if closeErr := conn.Close(context.Background()); closeErr != nil {
+			r.log.Errorf("Close-ListenHistory: %v", closeErr)
+		}
+	}()
+
+	if _, err = conn.Exec(ctx, "LISTEN "+historyChannel); err != nil {
+		return fmt.Errorf("Exec-ListenHistory: %w", err)
+	}
+
+	for {
+		notification, errWait := conn.WaitForNotification(ctx)
+		if errWait != nil {
+			return fmt.Errorf("WaitForNotification-ListenHistory: %w", errWait)
+		}
+
+		historyID, errParse := uuid.Parse(notification.Payload)
+		if errParse != nil {
+			r.log.Warnf("ListenHistory: invalid payload %q", notification.Payload)
+			continue
+		}
+
+		onChange(historyID)
+	}
+}
+
+func (r *Repository) GetHistoryByID(ctx context.Context, id uuid.UUID) (*models.History, error) {
+	query := fmt.Sprintf(queries.GetHistoryQuery, " WHERE id = $1", "", "")
+	rows, err := r.conn.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetHistoryByID: %w", err)
+	}
+	defer rows.Close()
+
+	histories, err := r.scanHistories(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(histories) == 0 {
+		return nil, apperrors.ErrHistoryNotFound
+	}
+
+	return histories[0], nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/google/uuid"
@@ -13,20 +14,11 @@ import (
 	"github.com/kstsm/wb-warehouse-control/internal/dto"
 	"github.com/kstsm/wb-warehouse-control/internal/models"
 	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+	"github.com/kstsm/wb-warehouse-control/pkg/cursor"
 )
 
 func (r *Repository) GetItemByID(ctx context.Context, itemID uuid.UUID) (*models.Item, error) {
-	var item models.Item
-
-	err := r.conn.QueryRow(ctx, queries.GetItemByIDQuery, itemID).Scan(
-		&item.ID,
-		&item.Name,
-		&item.Description,
-		&item.Quantity,
-		&item.Price,
-		&item.CreatedAt,
-		&item.UpdatedAt,
-	)
+	item, err := r.scanItem(r.conn.QueryRow(ctx, queries.GetItemByIDQuery, itemID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.ErrItemNotFound
@@ -34,78 +26,186 @@ func (r *Repository) GetItemByID(ctx context.Context, itemID uuid.UUID) (*models
 		return nil, fmt.Errorf("QueryRow-GetItemByID: %w", err)
 	}
 
-	return &item, nil
+	return item, nil
 }
 
-func (r *Repository) GetItems(ctx context.Context) ([]*models.Item, error) {
-	rows, err := r.conn.Query(ctx, queries.GetItemsQuery)
+func (r *Repository) GetItemBySKU(ctx context.Context, sku string) (*models.Item, error) {
+	item, err := r.scanItem(r.conn.QueryRow(ctx, queries.GetItemBySKUQuery, sku))
 	if err != nil {
-		return nil, fmt.Errorf("Query-GetItems: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-GetItemBySKU: %w", err)
 	}
-	defer rows.Close()
 
-	var items []*models.Item
-	for rows.Next() {
-		item := new(models.Item)
-		if errScan := rows.Scan(
-			&item.ID,
-			&item.Name,
-			&item.Description,
-			&item.Quantity,
-			&item.Price,
-			&item.CreatedAt,
-			&item.UpdatedAt,
-		); errScan != nil {
-			return nil, fmt.Errorf("Scan-GetItems: %w", errScan)
+	return item, nil
+}
+
+func (r *Repository) GetItemByBarcode(ctx context.Context, barcode string) (*models.Item, error) {
+	item, err := r.scanItem(r.conn.QueryRow(ctx, queries.GetItemByBarcodeQuery, barcode))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrItemNotFound
 		}
-		items = append(items, item)
+		return nil, fmt.Errorf("QueryRow-GetItemByBarcode: %w", err)
 	}
 
-	if errRows := rows.Err(); errRows != nil {
-		return nil, fmt.Errorf("GetItems rows.Err: %w", errRows)
+	return item, nil
+}
+
+func (r *Repository) GetItems(ctx context.Context, req dto.GetItemsRequest) ([]*models.Item, int, error) {
+	whereClause, args := r.buildItemsWhere(req)
+	orderClause := r.buildItemsOrder(req)
+
+	var total int
+	countQuery := fmt.Sprintf(queries.GetItemsCountQuery, whereClause)
+	if err := r.conn.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("QueryRow-GetItems: %w", err)
 	}
 
-	return items, nil
+	query := fmt.Sprintf(queries.GetItemsQuery, whereClause, orderClause, len(args)+1, len(args)+2)
+	args = append(args, req.Limit, req.Offset)
+
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Query-GetItems: %w", err)
+	}
+	defer rows.Close()
+
+	items, err := r.scanItems(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
 }
 
-func (r *Repository) GetHistoryByItemID(ctx context.Context, itemID uuid.UUID) ([]*models.History, error) {
-	rows, err := r.conn.Query(ctx, queries.GetHistoryByItemIDQuery, itemID)
+func (r *Repository) GetTrashItems(ctx context.Context, req dto.GetTrashItemsRequest) ([]*models.Item, int, error) {
+	var total int
+	if err := r.conn.QueryRow(ctx, queries.GetTrashItemsCountQuery).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("QueryRow-GetTrashItemsCount: %w", err)
+	}
+
+	rows, err := r.conn.Query(ctx, queries.GetTrashItemsQuery, req.Limit, req.Offset)
 	if err != nil {
-		return nil, fmt.Errorf("Query-GetHistoryByItemID: %w", err)
+		return nil, 0, fmt.Errorf("Query-GetTrashItems: %w", err)
 	}
 	defer rows.Close()
 
-	histories, err := r.scanHistories(rows)
+	items, err := r.scanItems(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+func (r *Repository) GetLowStockItems(
+	ctx context.Context,
+	req dto.GetLowStockItemsRequest,
+) ([]*models.Item, int, error) {
+	var total int
+	if err := r.conn.QueryRow(ctx, queries.GetLowStockItemsCountQuery).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("QueryRow-GetLowStockItemsCount: %w", err)
+	}
+
+	rows, err := r.conn.Query(ctx, queries.GetLowStockItemsQuery, req.Limit, req.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Query-GetLowStockItems: %w", err)
+	}
+	defer rows.Close()
+
+	items, err := r.scanItems(rows)
 	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+func (r *Repository) scanItem(row pgx.Row) (*models.Item, error) {
+	item := new(models.Item)
+	if err := row.Scan(
+		&item.ID,
+		&item.SKU,
+		&item.Barcodes,
+		&item.CategoryID,
+		&item.Name,
+		&item.Description,
+		&item.Attributes,
+		&item.Tags,
+		&item.ReorderPoint,
+		&item.Quantity,
+		&item.Price,
+		&item.Version,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+		&item.DeletedAt,
+	); err != nil {
 		return nil, err
 	}
 
-	return histories, nil
+	return item, nil
+}
+
+func (r *Repository) scanItems(rows pgx.Rows) ([]*models.Item, error) {
+	var items []*models.Item
+	for rows.Next() {
+		item, err := r.scanItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanItems scan: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("scanItems rows.Err: %w", err)
+	}
+
+	return items, nil
 }
 
-func (r *Repository) GetHistory(ctx context.Context, req dto.GetHistoryRequest) ([]*models.History, int, error) {
+func (r *Repository) GetHistory(ctx context.Context, req dto.GetHistoryRequest) (*models.HistoryPage, error) {
 	whereClause, args := r.buildHistoryWhere(req)
-	orderClause := r.buildHistoryOrder(req)
+	sortBy, sortOrder := r.historySort(req)
 
 	var total int
 	countQuery := fmt.Sprintf(queries.GetHistoryCountQuery, whereClause)
 	if err := r.conn.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
-		return nil, 0, fmt.Errorf("QueryRow-GetHistory: %w", err)
+		return nil, fmt.Errorf("QueryRow-GetHistory: %w", err)
 	}
 
-	query := fmt.Sprintf(queries.GetHistoryQuery, whereClause, orderClause)
+	whereClause, args = applyKeyset(whereClause, args, "changed_at", sortOrder, req.Cursor)
+	pageClause, args := applyLimitOffset(args, req.Limit, req.Offset)
+
+	orderClause := fmt.Sprintf(" ORDER BY %s %s, id %s", sortBy, sortOrder, sortOrder)
+	query := fmt.Sprintf(queries.GetHistoryQuery, whereClause, orderClause, pageClause)
 	rows, err := r.conn.Query(ctx, query, args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("Query-GetHistory: %w", err)
+		return nil, fmt.Errorf("Query-GetHistory: %w", err)
 	}
 	defer rows.Close()
 
 	histories, err := r.scanHistories(rows)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+
+	page := &models.HistoryPage{
+		Histories: histories,
+		Total:     total,
 	}
 
-	return histories, total, nil
+	if req.Limit > 0 && len(histories) > req.Limit {
+		page.Histories = histories[:req.Limit]
+		if sortBy == "changed_at" {
+			last := page.Histories[len(page.Histories)-1]
+			next := cursor.Encode(cursor.Cursor{Time: last.ChangedAt, ID: last.ID})
+			page.NextCursor = &next
+		}
+	}
+
+	return page, nil
 }
 
 func (r *Repository) scanHistories(rows pgx.Rows) ([]*models.History, error) {
@@ -122,6 +222,15 @@ func (r *Repository) scanHistories(rows pgx.Rows) ([]*models.History, error) {
 			&history.ChangedAt,
 			&oldDataJSON,
 			&newDataJSON,
+			&history.QuantityDelta,
+			&history.ReasonCode,
+			&history.Reference,
+			&history.WarehouseID,
+			&history.LocationID,
+			&history.RevertedFrom,
+			&history.Reason,
+			&history.RequestID,
+			&history.APIKeyID,
 		); err != nil {
 			return nil, fmt.Errorf("scanHistories scan: %w", err)
 		}
@@ -163,9 +272,27 @@ func (r *Repository) buildHistoryWhere(req dto.GetHistoryRequest) (string, []any
 	if req.UserID != nil {
 		add("user_id = $%d::uuid", *req.UserID)
 	}
+	if req.WarehouseID != nil {
+		add("warehouse_id = $%d::uuid", *req.WarehouseID)
+	}
+	if req.LocationID != nil {
+		add("location_id = $%d::uuid", *req.LocationID)
+	}
 	if req.Action != nil {
 		add("action = $%d", *req.Action)
 	}
+	if req.ReasonCode != nil {
+		add("reason_code = $%d", *req.ReasonCode)
+	}
+	if req.Reason != nil {
+		add("reason ILIKE '%%' || $%d || '%%'", *req.Reason)
+	}
+	if req.RequestID != nil {
+		add("request_id = $%d", *req.RequestID)
+	}
+	if req.APIKeyID != nil {
+		add("api_key_id = $%d::uuid", *req.APIKeyID)
+	}
 	if req.From != nil {
 		add("changed_at >= $%d", *req.From)
 	}
@@ -180,7 +307,7 @@ func (r *Repository) buildHistoryWhere(req dto.GetHistoryRequest) (string, []any
 	return " WHERE " + strings.Join(cond, " AND "), args
 }
 
-func (r *Repository) buildHistoryOrder(req dto.GetHistoryRequest) string {
+func (r *Repository) historySort(req dto.GetHistoryRequest) (string, string) {
 	sortBy := "changed_at"
 	sortOrder := "DESC"
 
@@ -201,5 +328,113 @@ func (r *Repository) buildHistoryOrder(req dto.GetHistoryRequest) string {
 		}
 	}
 
-	return fmt.Sprintf(" ORDER BY %s %s", sortBy, sortOrder)
+	return sortBy, sortOrder
+}
+
+func (r *Repository) buildItemsWhere(req dto.GetItemsRequest) (string, []any) {
+	cond := []string{"deleted_at IS NULL"}
+	var args []any
+
+	add := func(query string, val any) {
+		cond = append(cond, fmt.Sprintf(query, len(args)+1))
+		args = append(args, val)
+	}
+
+	if req.Name != nil {
+		add("name ILIKE '%%' || $%d || '%%'", *req.Name)
+	}
+	if req.MinQuantity != nil {
+		add("quantity >= $%d", *req.MinQuantity)
+	}
+	if req.MaxQuantity != nil {
+		add("quantity <= $%d", *req.MaxQuantity)
+	}
+	if req.MinPrice != nil {
+		add("price >= $%d", *req.MinPrice)
+	}
+	if req.MaxPrice != nil {
+		add("price <= $%d", *req.MaxPrice)
+	}
+	if req.CategoryID != nil {
+		add(`category_id IN (WITH RECURSIVE subtree AS (SELECT id
+		                                                 FROM categories
+		                                                 WHERE id = $%d::uuid
+		                                                 UNION ALL
+		                                                 SELECT c.id
+		                                                 FROM categories c
+		                                                          JOIN subtree s ON c.parent_id = s.id)
+		                     SELECT id
+		                     FROM subtree)`, *req.CategoryID)
+	}
+	if len(req.Tags) > 0 {
+		add("tags @> $%d::text[]", req.Tags)
+	}
+	if len(req.Attributes) > 0 {
+		keys := make([]string, 0, len(req.Attributes))
+		for key := range req.Attributes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			value := req.Attributes[key]
+			keyArg, valueArg := len(args)+1, len(args)+2
+			match := fmt.Sprintf("attributes @> jsonb_build_object($%d::text, $%d::text)", keyArg, valueArg)
+			if isJSONScalar(value) {
+				match = fmt.Sprintf("(%s OR attributes @> jsonb_build_object($%d::text, $%d::jsonb))",
+					match, keyArg, valueArg)
+			}
+			cond = append(cond, match)
+			args = append(args, key, value)
+		}
+	}
+	if req.WarehouseID != nil {
+		add(`EXISTS (SELECT 1
+		             FROM item_stocks s
+		             WHERE s.item_id = items.id
+		               AND s.warehouse_id = $%d::uuid
+		               AND s.quantity > 0)`, *req.WarehouseID)
+	}
+
+	return " WHERE " + strings.Join(cond, " AND "), args
+}
+
+func (r *Repository) buildItemsOrder(req dto.GetItemsRequest) string {
+	sortBy := "created_at"
+	sortOrder := "DESC"
+
+	if req.SortBy != nil {
+		allowedSortBy := map[string]string{
+			"name":       "name",
+			"quantity":   "quantity",
+			"price":      "price",
+			"created_at": "created_at",
+			"updated_at": "updated_at",
+		}
+		if allowed, ok := allowedSortBy[strings.ToLower(*req.SortBy)]; ok {
+			sortBy = allowed
+		}
+	}
+	if req.SortOrder != nil {
+		order := strings.ToUpper(*req.SortOrder)
+		if order == "ASC" || order == "DESC" {
+			sortOrder = order
+		}
+	}
+
+	return fmt.Sprintf(" ORDER BY %s %s, id %s", sortBy, sortOrder, sortOrder)
+}
+
+func isJSONScalar(value string) bool {
+	var decoded any
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return false
+	}
+
+	switch decoded.(type) {
+	case float64, bool:
+		return true
+	default:
+		return false
+	}
 }
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+)
+
+func (r *Repository) GetItemAsOf(ctx context.Context, id uuid.UUID, at time.Time) (*models.Item, error) {
+	item, err := r.scanItem(r.conn.QueryRow(ctx, queries.GetItemsAsOfQuery, at, id, true))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-GetItemAsOf: %w", err)
+	}
+
+	return item, nil
+}
+
+func (r *Repository) GetItemsAsOf(ctx context.Context, at time.Time) ([]*models.Item, error) {
+	rows, err := r.conn.Query(ctx, queries.GetItemsAsOfQuery, at, nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetItemsAsOf: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanItems(rows)
+}
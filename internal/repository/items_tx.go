@@ -8,10 +8,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/gookit/slog"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
 	"github.com/kstsm/wb-warehouse-control/internal/dto"
 	"github.com/kstsm/wb-warehouse-control/internal/models"
 	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+	"github.com/kstsm/wb-warehouse-control/pkg/apikey"
+	"github.com/kstsm/wb-warehouse-control/pkg/requestid"
 )
 
 func setUserIDInTx(ctx context.Context, tx pgx.Tx, userID *uuid.UUID) error {
@@ -26,7 +29,118 @@ func setUserIDInTx(ctx context.Context, tx pgx.Tx, userID *uuid.UUID) error {
 	return nil
 }
 
-func (r *Repository) CreateItem(ctx context.Context, item models.Item, userID *uuid.UUID) error {
+func setConfigInTx(ctx context.Context, tx pgx.Tx, key, value string) error {
+	if _, err := tx.Exec(ctx, "SELECT set_config($1, $2, true)", key, value); err != nil {
+		return fmt.Errorf("setConfigInTx %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func setWarehouseIDInTx(ctx context.Context, tx pgx.Tx, warehouseID *string) error {
+	if warehouseID == nil {
+		return nil
+	}
+
+	return setConfigInTx(ctx, tx, "app.warehouse_id", *warehouseID)
+}
+
+func setChangeContextInTx(ctx context.Context, tx pgx.Tx, reason dto.ChangeReason) error {
+	if requestID, ok := requestid.FromContext(ctx); ok {
+		if err := setConfigInTx(ctx, tx, "app.request_id", requestID); err != nil {
+			return err
+		}
+	}
+
+	if apiKeyID, ok := apikey.FromContext(ctx); ok {
+		if err := setConfigInTx(ctx, tx, "app.api_key_id", apiKeyID.String()); err != nil {
+			return err
+		}
+	}
+
+	if reason.ReasonCode != nil {
+		if err := setConfigInTx(ctx, tx, "app.reason_code", *reason.ReasonCode); err != nil {
+			return err
+		}
+	}
+
+	if reason.Reason != nil {
+		if err := setConfigInTx(ctx, tx, "app.reason", *reason.Reason); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isCheckViolation(err error) bool {
+	const checkViolationCode = "23514"
+
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == checkViolationCode
+}
+
+func isForeignKeyViolation(err error) bool {
+	const foreignKeyViolationCode = "23503"
+
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode
+}
+
+func isNotNullViolation(err error) bool {
+	const notNullViolationCode = "23502"
+
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == notNullViolationCode
+}
+
+func isUniqueViolation(err error) bool {
+	const uniqueViolationCode = "23505"
+
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+func itemForeignKeyError(err error) error {
+	if !isForeignKeyViolation(err) {
+		return nil
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+
+	switch pgErr.ConstraintName {
+	case "items_category_id_fkey":
+		return apperrors.ErrCategoryNotFound
+	case "item_stocks_warehouse_id_fkey", "stock_movements_warehouse_id_fkey":
+		return apperrors.ErrWarehouseNotFound
+	}
+
+	return nil
+}
+
+func itemUniqueError(err error) error {
+	if !isUniqueViolation(err) {
+		return nil
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName == "item_barcodes_pkey" {
+		return apperrors.ErrBarcodeExists
+	}
+
+	return apperrors.ErrSKUExists
+}
+
+func (r *Repository) CreateItem(
+	ctx context.Context,
+	item models.Item,
+	warehouseID *string,
+	reason dto.ChangeReason,
+	userID *uuid.UUID,
+) error {
 	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
 	if err != nil {
 		return fmt.Errorf("BeginTx-CreateItem: %w", err)
@@ -43,18 +157,42 @@ func (r *Repository) CreateItem(ctx context.Context, item models.Item, userID *u
 		return fmt.Errorf("setUserIDInTx-CreateItem: %w", errSetUser)
 	}
 
+	if errSet := setChangeContextInTx(ctx, tx, reason); errSet != nil {
+		return fmt.Errorf("setChangeContextInTx-CreateItem: %w", errSet)
+	}
+
+	if errSet := setWarehouseIDInTx(ctx, tx, warehouseID); errSet != nil {
+		return fmt.Errorf("setWarehouseIDInTx-CreateItem: %w", errSet)
+	}
+
 	if _, err = tx.Exec(ctx, queries.CreateItemQuery,
 		item.ID,
+		item.SKU,
+		item.Barcodes,
+		item.CategoryID,
 		item.Name,
 		item.Description,
+		item.Attributes,
+		item.Tags,
+		item.ReorderPoint,
 		item.Quantity,
 		item.Price,
 		item.CreatedAt,
 		item.UpdatedAt,
 	); err != nil {
+		if fkErr := itemForeignKeyError(err); fkErr != nil {
+			return fkErr
+		}
+		if uniqueErr := itemUniqueError(err); uniqueErr != nil {
+			return uniqueErr
+		}
 		return fmt.Errorf("Exec-CreateItem: %w", err)
 	}
 
+	if err = enqueueWebhookEventInTx(ctx, tx, models.WebhookEventItemCreated, item.ID, userID); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(context.Background()); err != nil {
 		return fmt.Errorf("Commit-CreateItem: %w", err)
 	}
@@ -84,36 +222,73 @@ func (r *Repository) UpdateItem(
 		return nil, fmt.Errorf("setUserIDInTx-UpdateItem: %w", errSetUser)
 	}
 
-	var item models.Item
-	if err = tx.QueryRow(ctx, queries.UpdateItemQuery,
+	if errSet := setChangeContextInTx(ctx, tx, req.ChangeReason); errSet != nil {
+		return nil, fmt.Errorf("setChangeContextInTx-UpdateItem: %w", errSet)
+	}
+
+	if errSet := setWarehouseIDInTx(ctx, tx, req.WarehouseID); errSet != nil {
+		return nil, fmt.Errorf("setWarehouseIDInTx-UpdateItem: %w", errSet)
+	}
+
+	item, err := r.scanItem(tx.QueryRow(ctx, queries.UpdateItemQuery,
 		itemID,
 		req.Name,
 		req.Description,
 		req.Quantity,
 		req.Price,
-	).Scan(
-		&item.ID,
-		&item.Name,
-		&item.Description,
-		&item.Quantity,
-		&item.Price,
-		&item.CreatedAt,
-		&item.UpdatedAt,
-	); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, apperrors.ErrItemNotFound
+		req.Version,
+		req.SKU,
+		req.Barcodes,
+		req.CategoryID,
+		req.Attributes,
+		req.Tags,
+		req.ReorderPoint,
+		req.ClearCategory,
+	))
+	if err != nil {
+		if fkErr := itemForeignKeyError(err); fkErr != nil {
+			return nil, fkErr
+		}
+
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, r.resolveUpdateMiss(ctx, tx, itemID)
+		case isCheckViolation(err):
+			return nil, apperrors.ErrInsufficientStock
+		case isUniqueViolation(err):
+			return nil, itemUniqueError(err)
 		}
 		return nil, fmt.Errorf("QueryRow-UpdateItem: %w", err)
 	}
 
+	if err = enqueueWebhookEventInTx(ctx, tx, models.WebhookEventItemUpdated, item.ID, userID); err != nil {
+		return nil, err
+	}
+
 	if err = tx.Commit(context.Background()); err != nil {
 		return nil, fmt.Errorf("Commit-UpdateItem: %w", err)
 	}
 
-	return &item, nil
+	return item, nil
 }
 
-func (r *Repository) DeleteItem(ctx context.Context, itemID uuid.UUID, userID *uuid.UUID) error {
+func (r *Repository) resolveUpdateMiss(ctx context.Context, tx pgx.Tx, itemID uuid.UUID) error {
+	if _, err := r.scanItem(tx.QueryRow(ctx, queries.GetItemByIDQuery, itemID)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.ErrItemNotFound
+		}
+		return fmt.Errorf("QueryRow-resolveUpdateMiss: %w", err)
+	}
+
+	return apperrors.ErrVersionConflict
+}
+
+func (r *Repository) DeleteItem(
+	ctx context.Context,
+	itemID uuid.UUID,
+	reason dto.ChangeReason,
+	userID *uuid.UUID,
+) error {
 	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
 	if err != nil {
 		return fmt.Errorf("BeginTx-DeleteItem: %w", err)
@@ -130,6 +305,10 @@ func (r *Repository) DeleteItem(ctx context.Context, itemID uuid.UUID, userID *u
 		return fmt.Errorf("setUserIDInTx-DeleteItem: %w", errSetUser)
 	}
 
+	if errSet := setChangeContextInTx(ctx, tx, reason); errSet != nil {
+		return fmt.Errorf("setChangeContextInTx-DeleteItem: %w", errSet)
+	}
+
 	var deletedID uuid.UUID
 	if err = tx.QueryRow(ctx, queries.DeleteItemQuery, itemID).Scan(&deletedID); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -138,9 +317,467 @@ func (r *Repository) DeleteItem(ctx context.Context, itemID uuid.UUID, userID *u
 		return fmt.Errorf("QueryRow-DeleteItem: %w", err)
 	}
 
+	if err = enqueueWebhookEventInTx(ctx, tx, models.WebhookEventItemDeleted, deletedID, userID); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(context.Background()); err != nil {
 		return fmt.Errorf("Commit-DeleteItem: %w", err)
 	}
 
 	return nil
 }
+
+func (r *Repository) RestoreItem(
+	ctx context.Context,
+	itemID uuid.UUID,
+	reason dto.ChangeReason,
+	userID *uuid.UUID,
+) (*models.Item, error) {
+	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("BeginTx-RestoreItem: %w", err)
+	}
+
+	defer func() {
+		rbErr := tx.Rollback(context.Background())
+		if rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			slog.Errorf("Rollback-RestoreItem: %v", rbErr)
+		}
+	}()
+
+	if errSetUser := setUserIDInTx(ctx, tx, userID); errSetUser != nil {
+		return nil, fmt.Errorf("setUserIDInTx-RestoreItem: %w", errSetUser)
+	}
+
+	if errSet := setChangeContextInTx(ctx, tx, reason); errSet != nil {
+		return nil, fmt.Errorf("setChangeContextInTx-RestoreItem: %w", errSet)
+	}
+
+	item, err := r.scanItem(tx.QueryRow(ctx, queries.RestoreItemQuery, itemID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-RestoreItem: %w", err)
+	}
+
+	if err = enqueueWebhookEventInTx(ctx, tx, models.WebhookEventItemUpdated, item.ID, userID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, fmt.Errorf("Commit-RestoreItem: %w", err)
+	}
+
+	return item, nil
+}
+
+func (r *Repository) RevertItem(
+	ctx context.Context,
+	itemID uuid.UUID,
+	historyID uuid.UUID,
+	snapshot map[string]any,
+	req dto.RevertItemRequest,
+	userID *uuid.UUID,
+) (*models.Item, error) {
+	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("BeginTx-RevertItem: %w", err)
+	}
+
+	defer func() {
+		rbErr := tx.Rollback(context.Background())
+		if rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			slog.Errorf("Rollback-RevertItem: %v", rbErr)
+		}
+	}()
+
+	if errSetUser := setUserIDInTx(ctx, tx, userID); errSetUser != nil {
+		return nil, fmt.Errorf("setUserIDInTx-RevertItem: %w", errSetUser)
+	}
+
+	if errSet := setChangeContextInTx(ctx, tx, req.ChangeReason); errSet != nil {
+		return nil, fmt.Errorf("setChangeContextInTx-RevertItem: %w", errSet)
+	}
+
+	if errSet := setWarehouseIDInTx(ctx, tx, req.WarehouseID); errSet != nil {
+		return nil, fmt.Errorf("setWarehouseIDInTx-RevertItem: %w", errSet)
+	}
+
+	if errSet := setConfigInTx(ctx, tx, "app.revert_history_id", historyID.String()); errSet != nil {
+		return nil, fmt.Errorf("setConfigInTx-RevertItem: %w", errSet)
+	}
+
+	var lockedID uuid.UUID
+	event := models.WebhookEventItemUpdated
+	query, args := queries.RevertItemQuery, []any{itemID, snapshot, req.Version}
+	if err = tx.QueryRow(ctx, queries.LockItemForRevertQuery, itemID).Scan(&lockedID); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("QueryRow-RevertItem lock: %w", err)
+		}
+		event = models.WebhookEventItemCreated
+		query, args = queries.RecreateItemQuery, []any{itemID, snapshot}
+	}
+
+	item, err := r.scanItem(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if fkErr := itemForeignKeyError(err); fkErr != nil {
+			return nil, fkErr
+		}
+
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, apperrors.ErrVersionConflict
+		case isCheckViolation(err):
+			return nil, apperrors.ErrInsufficientStock
+		case isNotNullViolation(err):
+			return nil, apperrors.ErrNotRevertible
+		case isUniqueViolation(err):
+			return nil, itemUniqueError(err)
+		}
+		return nil, fmt.Errorf("QueryRow-RevertItem: %w", err)
+	}
+
+	if err = enqueueWebhookEventInTx(ctx, tx, event, item.ID, userID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, fmt.Errorf("Commit-RevertItem: %w", err)
+	}
+
+	return item, nil
+}
+
+func (r *Repository) AdjustItemQuantity(
+	ctx context.Context,
+	itemID uuid.UUID,
+	req dto.AdjustStockRequest,
+	userID *uuid.UUID,
+) (*models.Item, error) {
+	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("BeginTx-AdjustItemQuantity: %w", err)
+	}
+
+	defer func() {
+		rbErr := tx.Rollback(context.Background())
+		if rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			slog.Errorf("Rollback-AdjustItemQuantity: %v", rbErr)
+		}
+	}()
+
+	if errSetUser := setUserIDInTx(ctx, tx, userID); errSetUser != nil {
+		return nil, fmt.Errorf("setUserIDInTx-AdjustItemQuantity: %w", errSetUser)
+	}
+
+	change := req.ChangeReason
+	change.Reason = req.Comment
+	if errSet := setChangeContextInTx(ctx, tx, change); errSet != nil {
+		return nil, fmt.Errorf("setChangeContextInTx-AdjustItemQuantity: %w", errSet)
+	}
+
+	if errSet := setConfigInTx(ctx, tx, "app.adjust_reason", req.Reason); errSet != nil {
+		return nil, fmt.Errorf("setConfigInTx-AdjustItemQuantity: %w", errSet)
+	}
+
+	if req.Reference != nil {
+		if errSet := setConfigInTx(ctx, tx, "app.adjust_reference", *req.Reference); errSet != nil {
+			return nil, fmt.Errorf("setConfigInTx-AdjustItemQuantity: %w", errSet)
+		}
+	}
+
+	if errSet := setWarehouseIDInTx(ctx, tx, req.WarehouseID); errSet != nil {
+		return nil, fmt.Errorf("setWarehouseIDInTx-AdjustItemQuantity: %w", errSet)
+	}
+
+	item, err := r.scanItem(tx.QueryRow(ctx, queries.AdjustItemQuantityQuery, itemID, req.Delta))
+	if err != nil {
+		if fkErr := itemForeignKeyError(err); fkErr != nil {
+			return nil, fkErr
+		}
+
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, apperrors.ErrItemNotFound
+		case isCheckViolation(err):
+			return nil, apperrors.ErrInsufficientStock
+		}
+		return nil, fmt.Errorf("QueryRow-AdjustItemQuantity: %w", err)
+	}
+
+	if err = enqueueWebhookEventInTx(ctx, tx, models.WebhookEventItemUpdated, item.ID, userID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, fmt.Errorf("Commit-AdjustItemQuantity: %w", err)
+	}
+
+	return item, nil
+}
+
+func (r *Repository) TransferStock(
+	ctx context.Context,
+	req dto.TransferRequest,
+	userID *uuid.UUID,
+) (*models.Transfer, error) {
+	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("BeginTx-TransferStock: %w", err)
+	}
+
+	defer func() {
+		rbErr := tx.Rollback(context.Background())
+		if rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			slog.Errorf("Rollback-TransferStock: %v", rbErr)
+		}
+	}()
+
+	if errSetUser := setUserIDInTx(ctx, tx, userID); errSetUser != nil {
+		return nil, fmt.Errorf("setUserIDInTx-TransferStock: %w", errSetUser)
+	}
+
+	if errSet := setChangeContextInTx(ctx, tx, req.ChangeReason); errSet != nil {
+		return nil, fmt.Errorf("setChangeContextInTx-TransferStock: %w", errSet)
+	}
+
+	if errSet := setConfigInTx(ctx, tx, "app.movement_kind", "transfer"); errSet != nil {
+		return nil, fmt.Errorf("setConfigInTx-TransferStock: %w", errSet)
+	}
+
+	if req.Reference != nil {
+		if errSet := setConfigInTx(ctx, tx, "app.adjust_reference", *req.Reference); errSet != nil {
+			return nil, fmt.Errorf("setConfigInTx-TransferStock: %w", errSet)
+		}
+	}
+
+	transfer := &models.Transfer{
+		ItemID:          uuid.MustParse(req.ItemID),
+		FromWarehouseID: uuid.MustParse(req.FromWarehouseID),
+		ToWarehouseID:   uuid.MustParse(req.ToWarehouseID),
+		Quantity:        req.Quantity,
+	}
+
+	if errLock := lockItemInTx(ctx, tx, transfer.ItemID); errLock != nil {
+		return nil, errLock
+	}
+
+	var found int
+	warehouseIDs := []uuid.UUID{transfer.FromWarehouseID, transfer.ToWarehouseID}
+	if err = tx.QueryRow(ctx, queries.CountWarehousesByIDsQuery, warehouseIDs).Scan(&found); err != nil {
+		return nil, fmt.Errorf("QueryRow-TransferStock: %w", err)
+	}
+	if found != len(warehouseIDs) {
+		return nil, apperrors.ErrWarehouseNotFound
+	}
+
+	var remaining int
+	if err = tx.QueryRow(ctx, queries.TransferStockOutQuery,
+		transfer.ItemID,
+		transfer.FromWarehouseID,
+		transfer.Quantity,
+	).Scan(&remaining); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isCheckViolation(err) {
+			return nil, apperrors.ErrInsufficientStock
+		}
+		return nil, fmt.Errorf("QueryRow-TransferStock: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, queries.TransferStockInQuery,
+		transfer.ItemID,
+		transfer.ToWarehouseID,
+		transfer.Quantity,
+	); err != nil {
+		return nil, fmt.Errorf("Exec-TransferStock: %w", err)
+	}
+
+	if transfer.Stocks, err = r.queryItemStocks(ctx, tx, transfer.ItemID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, fmt.Errorf("Commit-TransferStock: %w", err)
+	}
+
+	return transfer, nil
+}
+
+func (r *Repository) PutawayItem(
+	ctx context.Context,
+	req dto.PutawayRequest,
+	userID *uuid.UUID,
+) ([]models.ItemLocation, error) {
+	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("BeginTx-PutawayItem: %w", err)
+	}
+
+	defer func() {
+		rbErr := tx.Rollback(context.Background())
+		if rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			slog.Errorf("Rollback-PutawayItem: %v", rbErr)
+		}
+	}()
+
+	if errSetUser := setUserIDInTx(ctx, tx, userID); errSetUser != nil {
+		return nil, fmt.Errorf("setUserIDInTx-PutawayItem: %w", errSetUser)
+	}
+
+	if errSet := setChangeContextInTx(ctx, tx, req.ChangeReason); errSet != nil {
+		return nil, fmt.Errorf("setChangeContextInTx-PutawayItem: %w", errSet)
+	}
+
+	if errSet := setLocationActionInTx(ctx, tx, "putaway", req.Reference); errSet != nil {
+		return nil, fmt.Errorf("setLocationActionInTx-PutawayItem: %w", errSet)
+	}
+
+	itemID := uuid.MustParse(req.ItemID)
+	if errLock := lockItemInTx(ctx, tx, itemID); errLock != nil {
+		return nil, errLock
+	}
+
+	location, err := r.getLocationInTx(ctx, tx, uuid.MustParse(req.LocationID))
+	if err != nil {
+		return nil, err
+	}
+
+	var stock, placed int
+	if err = tx.QueryRow(ctx, queries.LockItemStockQuery, itemID, location.WarehouseID).Scan(&stock); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("QueryRow-PutawayItem: %w", err)
+		}
+	}
+
+	if err = tx.QueryRow(ctx, queries.GetPlacedQuantityQuery, itemID, location.WarehouseID).Scan(&placed); err != nil {
+		return nil, fmt.Errorf("QueryRow-PutawayItem: %w", err)
+	}
+
+	if stock-placed < req.Quantity {
+		return nil, apperrors.ErrInsufficientStock
+	}
+
+	if _, err = tx.Exec(ctx, queries.LocationStockInQuery, itemID, location.ID, req.Quantity); err != nil {
+		return nil, fmt.Errorf("Exec-PutawayItem: %w", err)
+	}
+
+	locations, err := r.queryItemLocations(ctx, tx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, fmt.Errorf("Commit-PutawayItem: %w", err)
+	}
+
+	return locations, nil
+}
+
+func (r *Repository) MoveItemLocation(
+	ctx context.Context,
+	req dto.MoveLocationRequest,
+	userID *uuid.UUID,
+) ([]models.ItemLocation, error) {
+	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("BeginTx-MoveItemLocation: %w", err)
+	}
+
+	defer func() {
+		rbErr := tx.Rollback(context.Background())
+		if rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			slog.Errorf("Rollback-MoveItemLocation: %v", rbErr)
+		}
+	}()
+
+	if errSetUser := setUserIDInTx(ctx, tx, userID); errSetUser != nil {
+		return nil, fmt.Errorf("setUserIDInTx-MoveItemLocation: %w", errSetUser)
+	}
+
+	if errSet := setChangeContextInTx(ctx, tx, req.ChangeReason); errSet != nil {
+		return nil, fmt.Errorf("setChangeContextInTx-MoveItemLocation: %w", errSet)
+	}
+
+	if errSet := setLocationActionInTx(ctx, tx, "relocate", req.Reference); errSet != nil {
+		return nil, fmt.Errorf("setLocationActionInTx-MoveItemLocation: %w", errSet)
+	}
+
+	itemID := uuid.MustParse(req.ItemID)
+	if errLock := lockItemInTx(ctx, tx, itemID); errLock != nil {
+		return nil, errLock
+	}
+
+	from, err := r.getLocationInTx(ctx, tx, uuid.MustParse(req.FromLocationID))
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := r.getLocationInTx(ctx, tx, uuid.MustParse(req.ToLocationID))
+	if err != nil {
+		return nil, err
+	}
+
+	if from.WarehouseID != to.WarehouseID {
+		return nil, apperrors.ErrLocationMismatch
+	}
+
+	var remaining int
+	if err = tx.QueryRow(ctx, queries.LocationStockOutQuery, itemID, from.ID, req.Quantity).Scan(&remaining); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isCheckViolation(err) {
+			return nil, apperrors.ErrInsufficientStock
+		}
+		return nil, fmt.Errorf("QueryRow-MoveItemLocation: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, queries.LocationStockInQuery, itemID, to.ID, req.Quantity); err != nil {
+		return nil, fmt.Errorf("Exec-MoveItemLocation: %w", err)
+	}
+
+	locations, err := r.queryItemLocations(ctx, tx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, fmt.Errorf("Commit-MoveItemLocation: %w", err)
+	}
+
+	return locations, nil
+}
+
+func setLocationActionInTx(ctx context.Context, tx pgx.Tx, action string, reference *string) error {
+	if err := setConfigInTx(ctx, tx, "app.location_action", action); err != nil {
+		return err
+	}
+
+	if reference == nil {
+		return nil
+	}
+
+	return setConfigInTx(ctx, tx, "app.adjust_reference", *reference)
+}
+
+func lockItemInTx(ctx context.Context, tx pgx.Tx, itemID uuid.UUID) error {
+	var lockedID uuid.UUID
+	if err := tx.QueryRow(ctx, queries.LockItemQuery, itemID).Scan(&lockedID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.ErrItemNotFound
+		}
+		return fmt.Errorf("QueryRow-lockItemInTx: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) getLocationInTx(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*models.StorageLocation, error) {
+	location, err := r.scanLocation(tx.QueryRow(ctx, queries.GetLocationByIDQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrLocationNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-getLocationInTx: %w", err)
+	}
+
+	return location, nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gookit/slog"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/pkg/validator"
+)
+
+func newTestRepository(t *testing.T) *Repository {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	conn, err := pgxpool.New(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf("pgxpool.New: %v", err)
+	}
+	t.Cleanup(conn.Close)
+
+	return &Repository{conn: conn, log: slog.New()}
+}
+
+func itemHistory(t *testing.T, repo *Repository, itemID uuid.UUID) []*models.History {
+	t.Helper()
+
+	id := itemID.String()
+	order := "asc"
+	page, err := repo.GetHistory(context.Background(), dto.GetHistoryRequest{ItemID: &id, SortOrder: &order})
+	if err != nil {
+		t.Fatalf("GetHistory: %v", err)
+	}
+
+	return page.Histories
+}
+
+func TestRevertArchivedItem(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	item := models.Item{
+		ID:        uuid.New(),
+		SKU:       "REVERT-" + uuid.NewString()[:8],
+		Name:      "Original",
+		Quantity:  5,
+		Price:     1000,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := repo.CreateItem(ctx, item, nil, dto.ChangeReason{}, nil); err != nil {
+		t.Fatalf("CreateItem: %v", err)
+	}
+
+	name, price := "Renamed", 2500
+	if _, err := repo.UpdateItem(ctx, item.ID, dto.UpdateItemRequest{Name: &name, Price: &price}, nil); err != nil {
+		t.Fatalf("UpdateItem: %v", err)
+	}
+	if err := repo.DeleteItem(ctx, item.ID, dto.ChangeReason{}, nil); err != nil {
+		t.Fatalf("DeleteItem: %v", err)
+	}
+
+	created := itemHistory(t, repo, item.ID)[0]
+	if created.Action != string(validator.ActionCreate) {
+		t.Fatalf("first history action = %s, want create", created.Action)
+	}
+
+	reverted, err := repo.RevertItem(ctx, item.ID, created.ID, created.NewData,
+		dto.RevertItemRequest{HistoryID: created.ID.String()}, nil)
+	if err != nil {
+		t.Fatalf("RevertItem: %v", err)
+	}
+
+	if reverted.DeletedAt != nil {
+		t.Fatalf("reverted item is still archived at %v", reverted.DeletedAt)
+	}
+	if reverted.Name != item.Name || reverted.Price != item.Price || reverted.Quantity != item.Quantity {
+		t.Fatalf("reverted item = %s/%d/%d, want %s/%d/%d",
+			reverted.Name, reverted.Price, reverted.Quantity, item.Name, item.Price, item.Quantity)
+	}
+	if reverted.Version != 4 {
+		t.Fatalf("reverted version = %d, want 4", reverted.Version)
+	}
+
+	history := itemHistory(t, repo, item.ID)
+	last := history[len(history)-1]
+	if last.Action != string(validator.ActionRestore) {
+		t.Fatalf("last history action = %s, want restore", last.Action)
+	}
+	if last.RevertedFrom == nil || *last.RevertedFrom != created.ID {
+		t.Fatalf("reverted_from = %v, want %s", last.RevertedFrom, created.ID)
+	}
+
+	var event string
+	if err = repo.conn.QueryRow(ctx, `
+		SELECT event_type
+		FROM webhook_outbox
+		WHERE item_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1`, item.ID).Scan(&event); err != nil {
+		t.Fatalf("select webhook event: %v", err)
+	}
+	if event != models.WebhookEventItemUpdated {
+		t.Fatalf("webhook event = %s, want %s", event, models.WebhookEventItemUpdated)
+	}
+}
+
+func TestItemForeignKeyError(t *testing.T) {
+	fkViolation := func(constraint string) error {
+		return fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23503", ConstraintName: constraint})
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "category", err: fkViolation("items_category_id_fkey"), want: apperrors.ErrCategoryNotFound},
+		{name: "item stock warehouse", err: fkViolation("item_stocks_warehouse_id_fkey"), want: apperrors.ErrWarehouseNotFound},
+		{name: "movement warehouse", err: fkViolation("stock_movements_warehouse_id_fkey"), want: apperrors.ErrWarehouseNotFound},
+		{name: "location", err: fkViolation("item_locations_location_id_fkey"), want: nil},
+		{name: "history user", err: fkViolation("items_history_user_id_fkey"), want: nil},
+		{name: "not a foreign key", err: &pgconn.PgError{Code: "23505", ConstraintName: "items_sku_key"}, want: nil},
+		{name: "plain error", err: errors.New("boom"), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := itemForeignKeyError(tt.err); !errors.Is(got, tt.want) {
+				t.Fatalf("itemForeignKeyError = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+)
+
+func (r *Repository) CreateLocation(
+	ctx context.Context,
+	location models.StorageLocation,
+) (*models.StorageLocation, error) {
+	created, err := r.scanLocation(r.conn.QueryRow(ctx, queries.CreateLocationQuery,
+		location.ID,
+		location.WarehouseID,
+		location.Zone,
+		location.Aisle,
+		location.Rack,
+		location.Bin,
+		location.CreatedAt,
+	))
+	if err != nil {
+		switch {
+		case isUniqueViolation(err):
+			return nil, apperrors.ErrLocationExists
+		case isForeignKeyViolation(err):
+			return nil, apperrors.ErrWarehouseNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-CreateLocation: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *Repository) GetLocations(ctx context.Context, warehouseID *uuid.UUID) ([]*models.StorageLocation, error) {
+	rows, err := r.conn.Query(ctx, queries.GetLocationsQuery, warehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetLocations: %w", err)
+	}
+	defer rows.Close()
+
+	var locations []*models.StorageLocation
+	for rows.Next() {
+		location, errScan := r.scanLocation(rows)
+		if errScan != nil {
+			return nil, fmt.Errorf("Scan-GetLocations: %w", errScan)
+		}
+		locations = append(locations, location)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetLocations rows.Err: %w", errRows)
+	}
+
+	return locations, nil
+}
+
+func (r *Repository) GetLocationByID(ctx context.Context, id uuid.UUID) (*models.StorageLocation, error) {
+	location, err := r.scanLocation(r.conn.QueryRow(ctx, queries.GetLocationByIDQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrLocationNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-GetLocationByID: %w", err)
+	}
+
+	return location, nil
+}
+
+func (r *Repository) GetLocationContents(ctx context.Context, id uuid.UUID) ([]*models.LocationContent, error) {
+	rows, err := r.conn.Query(ctx, queries.GetLocationContentsQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetLocationContents: %w", err)
+	}
+	defer rows.Close()
+
+	var contents []*models.LocationContent
+	for rows.Next() {
+		content := new(models.LocationContent)
+		if errScan := rows.Scan(&content.ItemID, &content.Name, &content.Quantity); errScan != nil {
+			return nil, fmt.Errorf("Scan-GetLocationContents: %w", errScan)
+		}
+		contents = append(contents, content)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetLocationContents rows.Err: %w", errRows)
+	}
+
+	return contents, nil
+}
+
+func (r *Repository) GetItemLocations(ctx context.Context, itemID uuid.UUID) ([]models.ItemLocation, error) {
+	return r.queryItemLocations(ctx, r.conn, itemID)
+}
+
+func (r *Repository) queryItemLocations(
+	ctx context.Context,
+	q rowQuerier,
+	itemID uuid.UUID,
+) ([]models.ItemLocation, error) {
+	rows, err := q.Query(ctx, queries.GetItemLocationsQuery, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetItemLocations: %w", err)
+	}
+	defer rows.Close()
+
+	locations := make([]models.ItemLocation, 0)
+	for rows.Next() {
+		var location models.ItemLocation
+		if errScan := rows.Scan(
+			&location.LocationID,
+			&location.LocationCode,
+			&location.WarehouseID,
+			&location.Quantity,
+		); errScan != nil {
+			return nil, fmt.Errorf("Scan-GetItemLocations: %w", errScan)
+		}
+		locations = append(locations, location)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetItemLocations rows.Err: %w", errRows)
+	}
+
+	return locations, nil
+}
+
+func (r *Repository) scanLocation(row pgx.Row) (*models.StorageLocation, error) {
+	location := new(models.StorageLocation)
+	if err := row.Scan(
+		&location.ID,
+		&location.WarehouseID,
+		&location.Zone,
+		&location.Aisle,
+		&location.Rack,
+		&location.Bin,
+		&location.Code,
+		&location.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
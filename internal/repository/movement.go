@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+	"github.com/kstsm/wb-warehouse-control/pkg/cursor"
+)
+
+func (r *Repository) GetMovements(ctx context.Context, req dto.GetMovementsRequest) (*models.StockMovementPage, error) {
+	whereClause, args := r.buildMovementsWhere(req)
+	sortBy, sortOrder := r.movementsSort(req)
+
+	page := new(models.StockMovementPage)
+	summaryQuery := fmt.Sprintf(queries.GetMovementsSummaryQuery, whereClause)
+	if err := r.conn.QueryRow(ctx, summaryQuery, args...).Scan(&page.Total, &page.TotalQuantity); err != nil {
+		return nil, fmt.Errorf("QueryRow-GetMovements: %w", err)
+	}
+
+	whereClause, args = applyKeyset(whereClause, args, "created_at", sortOrder, req.Cursor)
+	pageClause, args := applyLimitOffset(args, req.Limit, req.Offset)
+
+	orderClause := fmt.Sprintf(" ORDER BY %s %s, id %s", sortBy, sortOrder, sortOrder)
+	query := fmt.Sprintf(queries.GetMovementsQuery, whereClause, orderClause, pageClause)
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetMovements: %w", err)
+	}
+	defer rows.Close()
+
+	movements, err := r.scanMovements(rows)
+	if err != nil {
+		return nil, err
+	}
+	page.Movements = movements
+
+	if req.Limit > 0 && len(movements) > req.Limit {
+		page.Movements = movements[:req.Limit]
+		if sortBy == "created_at" {
+			last := page.Movements[len(page.Movements)-1]
+			next := cursor.Encode(cursor.Cursor{Time: last.CreatedAt, ID: last.ID})
+			page.NextCursor = &next
+		}
+	}
+
+	return page, nil
+}
+
+func (r *Repository) GetStockDiscrepancies(ctx context.Context) ([]*models.StockDiscrepancy, error) {
+	rows, err := r.conn.Query(ctx, queries.GetStockDiscrepanciesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetStockDiscrepancies: %w", err)
+	}
+	defer rows.Close()
+
+	var discrepancies []*models.StockDiscrepancy
+	for rows.Next() {
+		d := new(models.StockDiscrepancy)
+		if errScan := rows.Scan(&d.ItemID, &d.Name, &d.Quantity, &d.LedgerQuantity); errScan != nil {
+			return nil, fmt.Errorf("Scan-GetStockDiscrepancies: %w", errScan)
+		}
+		discrepancies = append(discrepancies, d)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetStockDiscrepancies rows.Err: %w", errRows)
+	}
+
+	return discrepancies, nil
+}
+
+func (r *Repository) scanMovements(rows pgx.Rows) ([]*models.StockMovement, error) {
+	var movements []*models.StockMovement
+	for rows.Next() {
+		movement := new(models.StockMovement)
+		if err := rows.Scan(
+			&movement.ID,
+			&movement.ItemID,
+			&movement.WarehouseID,
+			&movement.Kind,
+			&movement.Quantity,
+			&movement.Reference,
+			&movement.UserID,
+			&movement.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanMovements scan: %w", err)
+		}
+		movements = append(movements, movement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("scanMovements rows.Err: %w", err)
+	}
+
+	return movements, nil
+}
+
+func (r *Repository) buildMovementsWhere(req dto.GetMovementsRequest) (string, []any) {
+	var cond []string
+	var args []any
+
+	add := func(query string, val any) {
+		cond = append(cond, fmt.Sprintf(query, len(args)+1))
+		args = append(args, val)
+	}
+
+	if req.ItemID != nil {
+		add("item_id = $%d::uuid", *req.ItemID)
+	}
+	if req.UserID != nil {
+		add("user_id = $%d::uuid", *req.UserID)
+	}
+	if req.WarehouseID != nil {
+		add("warehouse_id = $%d::uuid", *req.WarehouseID)
+	}
+	if req.Kind != nil {
+		add("kind = $%d", *req.Kind)
+	}
+	if req.From != nil {
+		add("created_at >= $%d", *req.From)
+	}
+	if req.To != nil {
+		add("created_at <= $%d", *req.To)
+	}
+
+	if len(cond) == 0 {
+		return "", args
+	}
+
+	return " WHERE " + strings.Join(cond, " AND "), args
+}
+
+func (r *Repository) movementsSort(req dto.GetMovementsRequest) (string, string) {
+	sortBy := "created_at"
+	sortOrder := "DESC"
+
+	if req.SortBy != nil {
+		allowedSortBy := map[string]string{
+			"created_at": "created_at",
+			"kind":       "kind",
+			"quantity":   "quantity",
+			"user_id":    "user_id",
+		}
+		if allowed, ok := allowedSortBy[strings.ToLower(*req.SortBy)]; ok {
+			sortBy = allowed
+		}
+	}
+	if req.SortOrder != nil {
+		order := strings.ToUpper(*req.SortOrder)
+		if order == "ASC" || order == "DESC" {
+			sortOrder = order
+		}
+	}
+
+	return sortBy, sortOrder
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/kstsm/wb-warehouse-control/pkg/cursor"
+)
+
+func appendWhere(whereClause, cond string) string {
+	if whereClause == "" {
+		return " WHERE " + cond
+	}
+
+	return whereClause + " AND " + cond
+}
+
+func applyKeyset(
+	whereClause string,
+	args []any,
+	timeColumn string,
+	sortOrder string,
+	c *cursor.Cursor,
+) (string, []any) {
+	if c == nil {
+		return whereClause, args
+	}
+
+	op := "<"
+	if sortOrder == "ASC" {
+		op = ">"
+	}
+
+	cond := fmt.Sprintf("(%s, id) %s ($%d, $%d)", timeColumn, op, len(args)+1, len(args)+2)
+
+	return appendWhere(whereClause, cond), append(args, c.Time, c.ID)
+}
+
+func applyLimitOffset(args []any, limit, offset int) (string, []any) {
+	if limit <= 0 {
+		return "", args
+	}
+
+	clause := fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+
+	return clause, append(args, limit+1, offset)
+}
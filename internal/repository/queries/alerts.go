@@ -0,0 +1,61 @@
+package queries
+
+const (
+	GetAlertsQuery = `
+		SELECT a.id,
+		       a.item_id,
+		       i.sku,
+		       i.name,
+		       a.kind,
+		       a.quantity,
+		       a.reorder_point,
+		       a.created_at,
+		       a.acknowledged_at,
+		       a.acknowledged_by,
+		       a.api_key_id,
+		       a.acknowledged_api_key_id
+		FROM alerts a
+		         JOIN items i ON i.id = a.item_id
+		WHERE $1::BOOLEAN IS NULL
+		   OR (a.acknowledged_at IS NOT NULL) = $1
+		ORDER BY a.created_at DESC
+		LIMIT $2 OFFSET $3
+`
+
+	GetAlertsCountQuery = `
+		SELECT COUNT(*)
+		FROM alerts
+		WHERE $1::BOOLEAN IS NULL
+		   OR (acknowledged_at IS NOT NULL) = $1
+`
+
+	AcknowledgeAlertQuery = `
+		WITH acknowledged AS (
+			UPDATE alerts
+			SET acknowledged_at = NOW(),
+			    acknowledged_by = $2,
+			    acknowledged_api_key_id = $3
+			WHERE id = $1
+			  AND acknowledged_at IS NULL
+			RETURNING *
+		)
+		SELECT a.id,
+		       a.item_id,
+		       i.sku,
+		       i.name,
+		       a.kind,
+		       a.quantity,
+		       a.reorder_point,
+		       a.created_at,
+		       a.acknowledged_at,
+		       a.acknowledged_by,
+		       a.api_key_id,
+		       a.acknowledged_api_key_id
+		FROM acknowledged a
+		         JOIN items i ON i.id = a.item_id
+`
+
+	AlertExistsQuery = `
+		SELECT EXISTS (SELECT 1 FROM alerts WHERE id = $1)
+`
+)
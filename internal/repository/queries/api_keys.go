@@ -0,0 +1,91 @@
+package queries
+
+const (
+	CreateAPIKeyQuery = `
+		INSERT INTO api_keys (id,
+		                      name,
+		                      role,
+		                      key_prefix,
+		                      key_hash,
+		                      expires_at,
+		                      created_by,
+		                      created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, name, role, key_prefix, key_hash, expires_at, last_used_at, created_by, created_at, revoked_at
+`
+
+	GetAPIKeysQuery = `
+		SELECT id,
+		       name,
+		       role,
+		       key_prefix,
+		       key_hash,
+		       expires_at,
+		       last_used_at,
+		       created_by,
+		       created_at,
+		       revoked_at
+		FROM api_keys
+		ORDER BY created_at DESC, id
+`
+
+	GetAPIKeyByIDQuery = `
+		SELECT id,
+		       name,
+		       role,
+		       key_prefix,
+		       key_hash,
+		       expires_at,
+		       last_used_at,
+		       created_by,
+		       created_at,
+		       revoked_at
+		FROM api_keys
+		WHERE id = $1
+`
+
+	RevokeAPIKeyQuery = `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1
+		RETURNING id, name, role, key_prefix, key_hash, expires_at, last_used_at, created_by, created_at, revoked_at
+`
+
+	TouchAPIKeyQuery = `
+		WITH valid AS (SELECT id,
+		                      name,
+		                      role,
+		                      key_prefix,
+		                      key_hash,
+		                      expires_at,
+		                      last_used_at,
+		                      created_by,
+		                      created_at,
+		                      revoked_at
+		               FROM api_keys
+		               WHERE key_hash = $1
+		                 AND revoked_at IS NULL
+		                 AND (expires_at IS NULL OR expires_at > NOW())),
+		     touched AS (UPDATE api_keys
+		                 SET last_used_at = NOW()
+		                 WHERE id IN (SELECT a.id
+		                              FROM api_keys a
+		                                       JOIN valid v ON v.id = a.id
+		                              WHERE a.last_used_at IS NULL
+		                                 OR a.last_used_at < NOW() - INTERVAL '1 minute'
+		                              FOR UPDATE OF a SKIP LOCKED)
+		                 RETURNING id, last_used_at)
+		SELECT v.id,
+		       v.name,
+		       v.role,
+		       v.key_prefix,
+		       v.key_hash,
+		       v.expires_at,
+		       COALESCE(t.last_used_at, v.last_used_at),
+		       v.created_by,
+		       v.created_at,
+		       v.revoked_at
+		FROM valid v
+		         LEFT JOIN touched t ON t.id = v.id
+`
+)
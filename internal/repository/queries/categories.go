@@ -0,0 +1,73 @@
+package queries
+
+const (
+	CreateCategoryQuery = `
+		INSERT INTO categories (id,
+		                        parent_id,
+		                        name,
+		                        created_at,
+		                        updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+`
+
+	GetCategoriesQuery = `
+		WITH RECURSIVE tree AS (SELECT id, parent_id, name, created_at, updated_at, name::TEXT AS path
+		                        FROM categories
+		                        WHERE parent_id IS NULL
+		                        UNION ALL
+		                        SELECT c.id, c.parent_id, c.name, c.created_at, c.updated_at, t.path || ' / ' || c.name
+		                        FROM categories c
+		                                 JOIN tree t ON c.parent_id = t.id)
+		SELECT id,
+		       parent_id,
+		       name,
+		       path,
+		       created_at,
+		       updated_at
+		FROM tree
+		%s
+		ORDER BY path
+`
+
+	UpdateCategoryQuery = `
+		UPDATE categories
+		SET name = COALESCE($2, name),
+		    parent_id = CASE WHEN $4::BOOLEAN THEN $3::UUID ELSE parent_id END,
+		    updated_at = NOW()
+		WHERE id = $1
+		RETURNING id
+`
+
+	DeleteCategoryQuery = `
+		DELETE FROM categories
+		WHERE id = $1
+		RETURNING id
+`
+
+	LockCategoryMoveQuery = `
+		WITH RECURSIVE ancestors AS (SELECT id, parent_id
+		                             FROM categories
+		                             WHERE id = $2
+		                             UNION
+		                             SELECT c.id, c.parent_id
+		                             FROM categories c
+		                                      JOIN ancestors a ON c.id = a.parent_id)
+		SELECT id
+		FROM categories
+		WHERE id = $1
+		   OR id IN (SELECT id FROM ancestors)
+		ORDER BY id
+		FOR UPDATE
+`
+
+	IsCategoryDescendantQuery = `
+		WITH RECURSIVE subtree AS (SELECT id
+		                           FROM categories
+		                           WHERE id = $1
+		                           UNION ALL
+		                           SELECT c.id
+		                           FROM categories c
+		                                    JOIN subtree s ON c.parent_id = s.id)
+		SELECT EXISTS (SELECT 1 FROM subtree WHERE id = $2)
+`
+)
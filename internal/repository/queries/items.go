@@ -3,37 +3,169 @@ package queries
 const (
 	CreateItemQuery = `
 		INSERT INTO items (id,
+		                   sku,
+		                   barcodes,
+		                   category_id,
 		                   name,
 		                   description,
+		                   attributes,
+		                   tags,
+		                   reorder_point,
 		                   quantity,
 		                   price,
 		                   created_at,
 		                   updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 `
 
 	GetItemByIDQuery = `
 		SELECT id,
+		       sku,
+		       barcodes,
+		       category_id,
 		       name,
 		       description,
+		       attributes,
+		       tags,
+		       reorder_point,
 		       quantity,
 		       price,
+		       version,
 		       created_at,
-		       updated_at
+		       updated_at,
+		       deleted_at
 		FROM items
 		WHERE id = $1
+		  AND deleted_at IS NULL
+`
+
+	GetItemBySKUQuery = `
+		SELECT id,
+		       sku,
+		       barcodes,
+		       category_id,
+		       name,
+		       description,
+		       attributes,
+		       tags,
+		       reorder_point,
+		       quantity,
+		       price,
+		       version,
+		       created_at,
+		       updated_at,
+		       deleted_at
+		FROM items
+		WHERE sku = $1
+`
+
+	GetItemByBarcodeQuery = `
+		SELECT i.id,
+		       i.sku,
+		       i.barcodes,
+		       i.category_id,
+		       i.name,
+		       i.description,
+		       i.attributes,
+		       i.tags,
+		       i.reorder_point,
+		       i.quantity,
+		       i.price,
+		       i.version,
+		       i.created_at,
+		       i.updated_at,
+		       i.deleted_at
+		FROM item_barcodes b
+		         JOIN items i ON i.id = b.item_id
+		WHERE b.barcode = $1
 `
 
 	GetItemsQuery = `
 		SELECT id,
+		       sku,
+		       barcodes,
+		       category_id,
+		       name,
+		       description,
+		       attributes,
+		       tags,
+		       reorder_point,
+		       quantity,
+		       price,
+		       version,
+		       created_at,
+		       updated_at,
+		       deleted_at
+		FROM items
+		%s
+		%s
+		LIMIT $%d OFFSET $%d
+`
+
+	GetLowStockItemsQuery = `
+		SELECT id,
+		       sku,
+		       barcodes,
+		       category_id,
+		       name,
+		       description,
+		       attributes,
+		       tags,
+		       reorder_point,
+		       quantity,
+		       price,
+		       version,
+		       created_at,
+		       updated_at,
+		       deleted_at
+		FROM items
+		WHERE deleted_at IS NULL
+		  AND reorder_point IS NOT NULL
+		  AND quantity <= reorder_point
+		ORDER BY reorder_point - quantity DESC, name, id
+		LIMIT $1 OFFSET $2
+`
+
+	GetLowStockItemsCountQuery = `
+		SELECT COUNT(*)
+		FROM items
+		WHERE deleted_at IS NULL
+		  AND reorder_point IS NOT NULL
+		  AND quantity <= reorder_point
+`
+
+	GetItemsCountQuery = `
+		SELECT COUNT(*)
+		FROM items
+		%s
+`
+
+	GetTrashItemsQuery = `
+		SELECT id,
+		       sku,
+		       barcodes,
+		       category_id,
 		       name,
 		       description,
+		       attributes,
+		       tags,
+		       reorder_point,
 		       quantity,
 		       price,
+		       version,
 		       created_at,
-		       updated_at
+		       updated_at,
+		       deleted_at
+		FROM items
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+`
+
+	GetTrashItemsCountQuery = `
+		SELECT COUNT(*)
 		FROM items
-		ORDER BY created_at DESC
+		WHERE deleted_at IS NOT NULL
 `
 
 	UpdateItemQuery = `
@@ -43,17 +175,118 @@ const (
 			description = COALESCE($3, description),
 			quantity = COALESCE($4, quantity),
 			price = COALESCE($5, price),
+			sku = COALESCE($7, sku),
+			barcodes = COALESCE($8, barcodes),
+			category_id = CASE WHEN $13::BOOLEAN THEN NULL ELSE COALESCE($9, category_id) END,
+			attributes = COALESCE($10, attributes),
+			tags = COALESCE($11, tags),
+			reorder_point = COALESCE($12, reorder_point),
+			version = version + 1,
 			updated_at = NOW()
 		WHERE id = $1
-		RETURNING id, name, description, quantity, price, created_at, updated_at
+		  AND deleted_at IS NULL
+		  AND ($6::INT IS NULL OR version = $6)
+		RETURNING id, sku, barcodes, category_id, name, description, attributes, tags, reorder_point, quantity, price,
+		          version, created_at, updated_at, deleted_at
 `
 
 	DeleteItemQuery = `
-		DELETE FROM items
+		UPDATE items
+		SET deleted_at = NOW(),
+		    version = version + 1,
+		    updated_at = NOW()
 		WHERE id = $1
+		  AND deleted_at IS NULL
 		RETURNING id
 `
 
+	RestoreItemQuery = `
+		UPDATE items
+		SET deleted_at = NULL,
+		    version = version + 1,
+		    updated_at = NOW()
+		WHERE id = $1
+		  AND deleted_at IS NOT NULL
+		RETURNING id, sku, barcodes, category_id, name, description, attributes, tags, reorder_point, quantity, price,
+		          version, created_at, updated_at, deleted_at
+`
+
+	AdjustItemQuantityQuery = `
+		UPDATE items
+		SET quantity = quantity + $2,
+		    version = version + 1,
+		    updated_at = NOW()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+		RETURNING id, sku, barcodes, category_id, name, description, attributes, tags, reorder_point, quantity, price,
+		          version, created_at, updated_at, deleted_at
+`
+
+	RevertItemQuery = `
+		UPDATE items i
+		SET sku = COALESCE(s.sku, i.sku),
+		    barcodes = COALESCE(s.barcodes, i.barcodes),
+		    category_id = CASE WHEN $2::JSONB ? 'category_id' THEN s.category_id ELSE i.category_id END,
+		    name = COALESCE(s.name, i.name),
+		    description = COALESCE(s.description, i.description),
+		    attributes = COALESCE(s.attributes, i.attributes),
+		    tags = COALESCE(s.tags, i.tags),
+		    reorder_point = CASE WHEN $2::JSONB ? 'reorder_point' THEN s.reorder_point ELSE i.reorder_point END,
+		    quantity = COALESCE(s.quantity, i.quantity),
+		    price = COALESCE(s.price, i.price),
+		    version = i.version + 1,
+		    updated_at = NOW(),
+		    deleted_at = NULL
+		FROM jsonb_populate_record(NULL::items, $2::JSONB) s
+		WHERE i.id = $1
+		  AND ($3::INT IS NULL OR i.version = $3)
+		RETURNING i.id, i.sku, i.barcodes, i.category_id, i.name, i.description, i.attributes, i.tags, i.reorder_point,
+		          i.quantity, i.price, i.version, i.created_at, i.updated_at, i.deleted_at
+`
+
+	RecreateItemQuery = `
+		INSERT INTO items (id,
+		                   sku,
+		                   barcodes,
+		                   category_id,
+		                   name,
+		                   description,
+		                   attributes,
+		                   tags,
+		                   reorder_point,
+		                   quantity,
+		                   price,
+		                   version,
+		                   created_at,
+		                   updated_at)
+		SELECT $1,
+		       s.sku,
+		       COALESCE(s.barcodes, '{}'),
+		       s.category_id,
+		       s.name,
+		       COALESCE(s.description, ''),
+		       COALESCE(s.attributes, '{}'),
+		       COALESCE(s.tags, '{}'),
+		       s.reorder_point,
+		       COALESCE(s.quantity, 0),
+		       COALESCE(s.price, 0),
+		       (SELECT COALESCE(MAX((COALESCE(new_data, old_data) ->> 'version')::INT), 0) + 1
+		        FROM items_history
+		        WHERE item_id = $1),
+		       COALESCE(s.created_at, NOW()),
+		       NOW()
+		FROM jsonb_populate_record(NULL::items, $2::JSONB) s
+		RETURNING id, sku, barcodes, category_id, name, description, attributes, tags, reorder_point, quantity, price,
+		          version, created_at, updated_at, deleted_at
+`
+
+	LockItemForRevertQuery = `
+		SELECT id
+		FROM items
+		WHERE id = $1
+		FOR UPDATE
+`
+
 	GetHistoryQuery = `
 		SELECT id,
 		       item_id,
@@ -61,10 +294,20 @@ const (
 		       user_id,
 		       changed_at,
 		       old_data,
-		       new_data
+		       new_data,
+		       quantity_delta,
+		       reason_code,
+		       reference,
+		       warehouse_id,
+		       location_id,
+		       reverted_from,
+		       reason,
+		       request_id,
+		       api_key_id
 		FROM items_history
 		%s
 		%s
+		%s
 `
 
 	GetHistoryCountQuery = `
@@ -73,10 +316,73 @@ const (
 		%s
 `
 
-	GetHistoryByItemIDQuery = `
-		SELECT id, item_id, action, user_id, changed_at, old_data, new_data
+	GetItemsAsOfQuery = `
+		WITH latest AS (
+			SELECT DISTINCT ON (item_id) item_id, changed_at, new_data
+			FROM items_history
+			WHERE changed_at <= $1
+			  AND action IN ('create', 'update', 'delete', 'archive', 'restore', 'adjust')
+			  AND ($2::UUID IS NULL OR item_id = $2)
+			ORDER BY item_id, changed_at DESC, (new_data ->> 'version')::INT DESC NULLS FIRST, id DESC
+		)
+		SELECT l.item_id,
+		       COALESCE(s.sku, ''),
+		       COALESCE(s.barcodes, '{}'),
+		       s.category_id,
+		       COALESCE(s.name, ''),
+		       COALESCE(s.description, ''),
+		       COALESCE(s.attributes, '{}'),
+		       COALESCE(s.tags, '{}'),
+		       s.reorder_point,
+		       COALESCE(s.quantity, 0),
+		       COALESCE(s.price, 0),
+		       COALESCE(s.version, 0),
+		       COALESCE(s.created_at, l.changed_at),
+		       COALESCE(s.updated_at, l.changed_at),
+		       s.deleted_at
+		FROM latest l
+		CROSS JOIN LATERAL jsonb_populate_record(NULL::items, l.new_data) s
+		WHERE l.new_data IS NOT NULL
+		  AND ($3::BOOLEAN OR s.deleted_at IS NULL)
+		ORDER BY COALESCE(s.created_at, l.changed_at) DESC, l.item_id
+`
+
+	GetHistoryChainQuery = `
+		SELECT chain_seq,
+		       id,
+		       item_id,
+		       action::TEXT,
+		       user_id,
+		       changed_at,
+		       old_data::TEXT,
+		       new_data::TEXT,
+		       quantity_delta,
+		       reason_code,
+		       reference,
+		       warehouse_id,
+		       location_id,
+		       reverted_from,
+		       reason,
+		       request_id,
+		       api_key_id,
+		       prev_hash,
+		       hash
 		FROM items_history
-		WHERE item_id = $1
-		ORDER BY changed_at DESC
+		WHERE chain_seq > $1
+		ORDER BY chain_seq
+		LIMIT $2
+`
+
+	GetLatestHistoryCheckpointQuery = `
+		SELECT chain_seq, hash
+		FROM history_chain_checkpoints
+		ORDER BY chain_seq DESC
+		LIMIT 1
+`
+
+	SaveHistoryCheckpointQuery = `
+		INSERT INTO history_chain_checkpoints (chain_seq, hash)
+		VALUES ($1, $2)
+		ON CONFLICT (chain_seq) DO NOTHING
 `
 )
@@ -0,0 +1,98 @@
+package queries
+
+const (
+	CreateLocationQuery = `
+		INSERT INTO storage_locations (id,
+		                               warehouse_id,
+		                               zone,
+		                               aisle,
+		                               rack,
+		                               bin,
+		                               created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, warehouse_id, zone, aisle, rack, bin, code, created_at
+`
+
+	GetLocationsQuery = `
+		SELECT id,
+		       warehouse_id,
+		       zone,
+		       aisle,
+		       rack,
+		       bin,
+		       code,
+		       created_at
+		FROM storage_locations
+		WHERE ($1::uuid IS NULL OR warehouse_id = $1)
+		ORDER BY warehouse_id, code
+`
+
+	GetLocationByIDQuery = `
+		SELECT id,
+		       warehouse_id,
+		       zone,
+		       aisle,
+		       rack,
+		       bin,
+		       code,
+		       created_at
+		FROM storage_locations
+		WHERE id = $1
+`
+
+	GetLocationContentsQuery = `
+		SELECT ls.item_id,
+		       i.name,
+		       ls.quantity
+		FROM location_stocks ls
+		         JOIN items i ON i.id = ls.item_id
+		WHERE ls.location_id = $1
+		  AND ls.quantity > 0
+		ORDER BY i.name
+`
+
+	GetItemLocationsQuery = `
+		SELECT ls.location_id,
+		       l.code,
+		       l.warehouse_id,
+		       ls.quantity
+		FROM location_stocks ls
+		         JOIN storage_locations l ON l.id = ls.location_id
+		WHERE ls.item_id = $1
+		  AND ls.quantity > 0
+		ORDER BY l.warehouse_id, l.code
+`
+
+	LockItemStockQuery = `
+		SELECT quantity
+		FROM item_stocks
+		WHERE item_id = $1
+		  AND warehouse_id = $2
+		FOR UPDATE
+`
+
+	GetPlacedQuantityQuery = `
+		SELECT COALESCE(SUM(ls.quantity), 0)
+		FROM location_stocks ls
+		         JOIN storage_locations l ON l.id = ls.location_id
+		WHERE ls.item_id = $1
+		  AND l.warehouse_id = $2
+`
+
+	LocationStockOutQuery = `
+		UPDATE location_stocks
+		SET quantity = quantity - $3,
+		    updated_at = NOW()
+		WHERE item_id = $1
+		  AND location_id = $2
+		RETURNING quantity
+`
+
+	LocationStockInQuery = `
+		INSERT INTO location_stocks (item_id, location_id, quantity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (item_id, location_id) DO UPDATE
+		    SET quantity = location_stocks.quantity + EXCLUDED.quantity,
+		        updated_at = NOW()
+`
+)
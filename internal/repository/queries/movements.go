@@ -0,0 +1,37 @@
+package queries
+
+const (
+	GetMovementsQuery = `
+		SELECT id,
+		       item_id,
+		       warehouse_id,
+		       kind,
+		       quantity,
+		       reference,
+		       user_id,
+		       created_at
+		FROM stock_movements
+		%s
+		%s
+		%s
+`
+
+	GetMovementsSummaryQuery = `
+		SELECT COUNT(*),
+		       COALESCE(SUM(quantity), 0)
+		FROM stock_movements
+		%s
+`
+
+	GetStockDiscrepanciesQuery = `
+		SELECT i.id,
+		       i.name,
+		       i.quantity,
+		       COALESCE(SUM(m.quantity), 0) AS ledger_quantity
+		FROM items i
+		         LEFT JOIN stock_movements m ON m.item_id = i.id
+		GROUP BY i.id, i.name, i.quantity
+		HAVING i.quantity <> COALESCE(SUM(m.quantity), 0)
+		ORDER BY i.name
+`
+)
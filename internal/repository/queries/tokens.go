@@ -0,0 +1,75 @@
+package queries
+
+const (
+	CreateRefreshTokenQuery = `
+		INSERT INTO refresh_tokens (id,
+		                            user_id,
+		                            family_id,
+		                            token_hash,
+		                            expires_at,
+		                            created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+	GetRefreshTokenForUpdateQuery = `
+		SELECT id,
+		       user_id,
+		       family_id,
+		       token_hash,
+		       expires_at,
+		       created_at,
+		       revoked_at,
+		       replaced_by
+		FROM refresh_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+`
+
+	ReplaceRefreshTokenQuery = `
+		UPDATE refresh_tokens
+		SET revoked_at  = NOW(),
+		    replaced_by = $2
+		WHERE id = $1
+`
+
+	RevokeRefreshTokenFamilyQuery = `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE family_id = $1
+		  AND revoked_at IS NULL
+`
+
+	RevokeRefreshTokenQuery = `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE token_hash = $1 AND user_id = $2)
+		  AND revoked_at IS NULL
+`
+
+	RevokeUserRefreshTokensQuery = `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE user_id = $1
+		  AND revoked_at IS NULL
+`
+
+	RevokeAccessTokenQuery = `
+		INSERT INTO revoked_tokens (jti, user_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING
+`
+
+	IsTokenRevokedQuery = `
+		SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)
+`
+
+	DeleteExpiredRefreshTokensQuery = `
+		DELETE FROM refresh_tokens
+		WHERE expires_at < NOW()
+`
+
+	DeleteExpiredRevokedTokensQuery = `
+		DELETE FROM revoked_tokens
+		WHERE expires_at < NOW()
+`
+)
@@ -5,21 +5,111 @@ const (
 		SELECT id,
 		       name,
 		       role,
+		       password_hash,
+		       disabled_at,
+		       deleted_at,
 		       created_at,
 		       updated_at
 		FROM users
 		WHERE name = $1
 `
 
-	GetOrCreateUserQuery = `
-	INSERT INTO users (id,
-	                   name,
-	                   role,
-	                   created_at,
-	                   updated_at)
-	VALUES ($1, $2, $3, $4, $5)
-	ON CONFLICT (name) DO UPDATE
-	SET name = users.name
-	RETURNING id, name, role, created_at, updated_at;
+	GetUserByIDQuery = `
+		SELECT id,
+		       name,
+		       role,
+		       password_hash,
+		       disabled_at,
+		       deleted_at,
+		       created_at,
+		       updated_at
+		FROM users
+		WHERE id = $1
+		  AND deleted_at IS NULL
+`
+
+	GetUsersQuery = `
+		SELECT id,
+		       name,
+		       role,
+		       password_hash,
+		       disabled_at,
+		       deleted_at,
+		       created_at,
+		       updated_at
+		FROM users
+		WHERE deleted_at IS NULL
+		ORDER BY created_at, id
+`
+
+	CreateUserQuery = `
+		INSERT INTO users (id,
+		                   name,
+		                   role,
+		                   password_hash,
+		                   created_at,
+		                   updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, name, role, password_hash, disabled_at, deleted_at, created_at, updated_at
+`
+
+	UpdateUserQuery = `
+		UPDATE users
+		SET role          = COALESCE($2, role),
+		    password_hash = COALESCE($3, password_hash),
+		    disabled_at   = CASE
+		                        WHEN $4::BOOLEAN IS NULL THEN disabled_at
+		                        WHEN $4::BOOLEAN THEN COALESCE(disabled_at, NOW())
+		                        ELSE NULL
+		                    END,
+		    updated_at    = NOW()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+		RETURNING id, name, role, password_hash, disabled_at, deleted_at, created_at, updated_at
+`
+
+	LockActiveAdminsQuery = `
+		SELECT COUNT(*)
+		FROM (SELECT id
+		      FROM users
+		      WHERE role = 'admin'
+		        AND disabled_at IS NULL
+		        AND deleted_at IS NULL
+		      FOR UPDATE) admins
+`
+
+	CountActiveAdminsQuery = `
+		SELECT COUNT(*)
+		FROM users
+		WHERE role = 'admin'
+		  AND disabled_at IS NULL
+		  AND deleted_at IS NULL
+`
+
+	DeleteUserQuery = `
+		UPDATE users
+		SET deleted_at  = NOW(),
+		    disabled_at = COALESCE(disabled_at, NOW()),
+		    updated_at  = NOW()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+		RETURNING id
+`
+
+	GetUserHistoryQuery = `
+		SELECT id,
+		       user_id,
+		       action,
+		       changed_by,
+		       changed_at,
+		       old_data,
+		       new_data,
+		       reason_code,
+		       reason,
+		       request_id,
+		       api_key_id
+		FROM users_history
+		WHERE user_id = $1
+		ORDER BY changed_at DESC, id
 `
 )
@@ -0,0 +1,81 @@
+package queries
+
+const (
+	CreateWarehouseQuery = `
+		INSERT INTO warehouses (id,
+		                        code,
+		                        name,
+		                        address,
+		                        created_at,
+		                        updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, code, name, address, is_default, created_at, updated_at
+`
+
+	GetWarehousesQuery = `
+		SELECT id,
+		       code,
+		       name,
+		       address,
+		       is_default,
+		       created_at,
+		       updated_at
+		FROM warehouses
+		ORDER BY is_default DESC, code
+`
+
+	GetWarehouseByIDQuery = `
+		SELECT id,
+		       code,
+		       name,
+		       address,
+		       is_default,
+		       created_at,
+		       updated_at
+		FROM warehouses
+		WHERE id = $1
+`
+
+	CountWarehousesByIDsQuery = `
+		SELECT COUNT(*)
+		FROM warehouses
+		WHERE id = ANY ($1::uuid[])
+`
+
+	GetItemStocksQuery = `
+		SELECT s.warehouse_id,
+		       w.code,
+		       w.name,
+		       s.quantity
+		FROM item_stocks s
+		         JOIN warehouses w ON w.id = s.warehouse_id
+		WHERE s.item_id = $1
+		  AND s.quantity > 0
+		ORDER BY w.is_default DESC, w.code
+`
+
+	LockItemQuery = `
+		SELECT id
+		FROM items
+		WHERE id = $1
+		  AND deleted_at IS NULL
+		FOR UPDATE
+`
+
+	TransferStockOutQuery = `
+		UPDATE item_stocks
+		SET quantity = quantity - $3,
+		    updated_at = NOW()
+		WHERE item_id = $1
+		  AND warehouse_id = $2
+		RETURNING quantity
+`
+
+	TransferStockInQuery = `
+		INSERT INTO item_stocks (item_id, warehouse_id, quantity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (item_id, warehouse_id) DO UPDATE
+		    SET quantity = item_stocks.quantity + EXCLUDED.quantity,
+		        updated_at = NOW()
+`
+)
@@ -0,0 +1,166 @@
+package queries
+
+const (
+	CreateWebhookQuery = `
+		INSERT INTO webhook_subscriptions (id,
+		                                   url,
+		                                   events,
+		                                   secret,
+		                                   is_active,
+		                                   created_at,
+		                                   updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, url, events, secret, is_active, created_at, updated_at
+`
+
+	GetWebhooksQuery = `
+		SELECT id,
+		       url,
+		       events,
+		       secret,
+		       is_active,
+		       created_at,
+		       updated_at
+		FROM webhook_subscriptions
+		ORDER BY created_at
+`
+
+	GetWebhookByIDQuery = `
+		SELECT id,
+		       url,
+		       events,
+		       secret,
+		       is_active,
+		       created_at,
+		       updated_at
+		FROM webhook_subscriptions
+		WHERE id = $1
+`
+
+	UpdateWebhookQuery = `
+		UPDATE webhook_subscriptions
+		SET url = COALESCE($2, url),
+		    events = COALESCE($3, events),
+		    secret = COALESCE($4, secret),
+		    is_active = COALESCE($5, is_active),
+		    updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, url, events, secret, is_active, created_at, updated_at
+`
+
+	DeleteWebhookQuery = `
+		DELETE
+		FROM webhook_subscriptions
+		WHERE id = $1
+`
+
+	GetWebhookDeliveriesQuery = `
+		SELECT d.id,
+		       d.subscription_id,
+		       d.event_id,
+		       o.event_type,
+		       o.item_id,
+		       d.status,
+		       d.attempts,
+		       d.next_attempt_at,
+		       d.response_status,
+		       d.last_error,
+		       d.created_at,
+		       d.delivered_at
+		FROM webhook_deliveries d
+		         JOIN webhook_outbox o ON o.id = d.event_id
+		WHERE d.subscription_id = $1
+		  AND ($2::TEXT IS NULL OR d.status = $2)
+		ORDER BY d.created_at DESC
+		LIMIT $3 OFFSET $4
+`
+
+	GetWebhookDeliveriesCountQuery = `
+		SELECT COUNT(*)
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+		  AND ($2::TEXT IS NULL OR status = $2)
+`
+
+	EnqueueWebhookEventQuery = `
+		INSERT INTO webhook_outbox (id, event_type, item_id, user_id, payload)
+		SELECT $1, $2, i.id, $4, item_snapshot(i)
+		FROM items i
+		WHERE i.id = $3
+`
+
+	FanOutWebhookEventsQuery = `
+		WITH events AS (SELECT id, event_type
+		                FROM webhook_outbox
+		                WHERE processed_at IS NULL
+		                ORDER BY created_at
+		                LIMIT $1 FOR UPDATE SKIP LOCKED),
+		     fanned AS (INSERT INTO webhook_deliveries (id, subscription_id, event_id)
+		                SELECT gen_random_uuid(), s.id, e.id
+		                FROM events e
+		                         JOIN webhook_subscriptions s ON s.is_active AND e.event_type = ANY (s.events)
+		                ON CONFLICT (subscription_id, event_id) DO NOTHING)
+		UPDATE webhook_outbox o
+		SET processed_at = NOW()
+		FROM events e
+		WHERE o.id = e.id
+`
+
+	ClaimWebhookDeliveriesQuery = `
+		WITH due AS (SELECT id
+		             FROM webhook_deliveries
+		             WHERE status = 'pending'
+		               AND next_attempt_at <= NOW()
+		             ORDER BY next_attempt_at
+		             LIMIT $1 FOR UPDATE SKIP LOCKED),
+		     claimed AS (UPDATE webhook_deliveries d
+		                 SET next_attempt_at = NOW() + $2::INTERVAL
+		                 FROM due
+		                 WHERE d.id = due.id
+		                 RETURNING d.id, d.attempts, d.subscription_id, d.event_id)
+		SELECT c.id,
+		       c.attempts,
+		       s.url,
+		       s.secret,
+		       o.id,
+		       o.event_type,
+		       o.item_id,
+		       o.user_id,
+		       o.api_key_id,
+		       o.payload,
+		       o.created_at
+		FROM claimed c
+		         JOIN webhook_subscriptions s ON s.id = c.subscription_id
+		         JOIN webhook_outbox o ON o.id = c.event_id
+`
+
+	MarkWebhookDeliveredQuery = `
+		UPDATE webhook_deliveries
+		SET status = 'delivered',
+		    attempts = attempts + 1,
+		    response_status = $2,
+		    last_error = NULL,
+		    delivered_at = NOW()
+		WHERE id = $1
+`
+
+	MarkWebhookAttemptFailedQuery = `
+		UPDATE webhook_deliveries
+		SET status = $4,
+		    attempts = attempts + 1,
+		    response_status = $2,
+		    last_error = $3,
+		    next_attempt_at = $5
+		WHERE id = $1
+`
+
+	DeleteProcessedWebhookEventsQuery = `
+		DELETE
+		FROM webhook_outbox o
+		WHERE o.processed_at < $1
+		  AND NOT EXISTS (SELECT 1
+		                  FROM webhook_deliveries d
+		                  WHERE d.event_id = o.id
+		                    AND d.status = 'pending')
+`
+)
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gookit/slog"
@@ -11,14 +12,115 @@ import (
 )
 
 type ItemManager interface {
-	CreateItem(ctx context.Context, item models.Item, userID *uuid.UUID) error
-	GetOrCreateUser(ctx context.Context, user models.User) (*models.User, error)
+	CreateItem(
+		ctx context.Context,
+		item models.Item,
+		warehouseID *string,
+		reason dto.ChangeReason,
+		userID *uuid.UUID,
+	) error
+	CreateUser(ctx context.Context, user models.User, actorID *uuid.UUID) (*models.User, error)
+	GetUsers(ctx context.Context) ([]*models.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	GetUserByName(ctx context.Context, userName string) (*models.User, error)
+	UpdateUser(
+		ctx context.Context,
+		id uuid.UUID,
+		update models.UserUpdate,
+		reason dto.ChangeReason,
+		actorID *uuid.UUID,
+	) (*models.User, error)
+	DeleteUser(ctx context.Context, id uuid.UUID, reason dto.ChangeReason, actorID *uuid.UUID) error
+	GetUserHistory(ctx context.Context, userID uuid.UUID) ([]*models.UserHistory, error)
+	CreateRefreshToken(ctx context.Context, token models.RefreshToken) error
+	RotateRefreshToken(ctx context.Context, tokenHash string, next models.RefreshToken) (*models.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, tokenHash string, userID uuid.UUID) error
+	RevokeAccessToken(ctx context.Context, jti, userID uuid.UUID, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error)
+	DeleteExpiredTokens(ctx context.Context) (int64, error)
+	CreateAPIKey(ctx context.Context, key models.APIKey) (*models.APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+	GetAPIKeyByID(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+	TouchAPIKey(ctx context.Context, keyHash string) (*models.APIKey, error)
 	GetItemByID(ctx context.Context, id uuid.UUID) (*models.Item, error)
-	GetItems(ctx context.Context) ([]*models.Item, error)
+	GetItemBySKU(ctx context.Context, sku string) (*models.Item, error)
+	GetItemByBarcode(ctx context.Context, barcode string) (*models.Item, error)
+	GetItems(ctx context.Context, req dto.GetItemsRequest) ([]*models.Item, int, error)
+	GetTrashItems(ctx context.Context, req dto.GetTrashItemsRequest) ([]*models.Item, int, error)
 	UpdateItem(ctx context.Context, id uuid.UUID, req dto.UpdateItemRequest, userID *uuid.UUID) (*models.Item, error)
-	DeleteItem(ctx context.Context, id uuid.UUID, userID *uuid.UUID) error
-	GetHistory(ctx context.Context, req dto.GetHistoryRequest) ([]*models.History, int, error)
-	GetHistoryByItemID(ctx context.Context, itemID uuid.UUID) ([]*models.History, error)
+	DeleteItem(ctx context.Context, id uuid.UUID, reason dto.ChangeReason, userID *uuid.UUID) error
+	RestoreItem(ctx context.Context, id uuid.UUID, reason dto.ChangeReason, userID *uuid.UUID) (*models.Item, error)
+	AdjustItemQuantity(
+		ctx context.Context,
+		id uuid.UUID,
+		req dto.AdjustStockRequest,
+		userID *uuid.UUID,
+	) (*models.Item, error)
+	GetHistory(ctx context.Context, req dto.GetHistoryRequest) (*models.HistoryPage, error)
+	GetMovements(ctx context.Context, req dto.GetMovementsRequest) (*models.StockMovementPage, error)
+	GetStockDiscrepancies(ctx context.Context) ([]*models.StockDiscrepancy, error)
+	CreateWarehouse(ctx context.Context, warehouse models.Warehouse) (*models.Warehouse, error)
+	GetWarehouses(ctx context.Context) ([]*models.Warehouse, error)
+	GetWarehouseByID(ctx context.Context, id uuid.UUID) (*models.Warehouse, error)
+	GetItemStocks(ctx context.Context, itemID uuid.UUID) ([]models.ItemStock, error)
+	TransferStock(ctx context.Context, req dto.TransferRequest, userID *uuid.UUID) (*models.Transfer, error)
+	CreateLocation(ctx context.Context, location models.StorageLocation) (*models.StorageLocation, error)
+	GetLocations(ctx context.Context, warehouseID *uuid.UUID) ([]*models.StorageLocation, error)
+	GetLocationByID(ctx context.Context, id uuid.UUID) (*models.StorageLocation, error)
+	GetLocationContents(ctx context.Context, id uuid.UUID) ([]*models.LocationContent, error)
+	GetItemLocations(ctx context.Context, itemID uuid.UUID) ([]models.ItemLocation, error)
+	PutawayItem(ctx context.Context, req dto.PutawayRequest, userID *uuid.UUID) ([]models.ItemLocation, error)
+	MoveItemLocation(
+		ctx context.Context,
+		req dto.MoveLocationRequest,
+		userID *uuid.UUID,
+	) ([]models.ItemLocation, error)
+	CreateCategory(ctx context.Context, category models.Category) (*models.Category, error)
+	GetCategories(ctx context.Context) ([]*models.Category, error)
+	GetCategoryByID(ctx context.Context, id uuid.UUID) (*models.Category, error)
+	UpdateCategory(ctx context.Context, id uuid.UUID, req dto.UpdateCategoryRequest) (*models.Category, error)
+	DeleteCategory(ctx context.Context, id uuid.UUID) error
+	GetLowStockItems(ctx context.Context, req dto.GetLowStockItemsRequest) ([]*models.Item, int, error)
+	GetItemAsOf(ctx context.Context, id uuid.UUID, at time.Time) (*models.Item, error)
+	RevertItem(
+		ctx context.Context,
+		itemID uuid.UUID,
+		historyID uuid.UUID,
+		snapshot map[string]any,
+		req dto.RevertItemRequest,
+		userID *uuid.UUID,
+	) (*models.Item, error)
+	GetItemsAsOf(ctx context.Context, at time.Time) ([]*models.Item, error)
+	GetAlerts(ctx context.Context, req dto.GetAlertsRequest) ([]*models.Alert, int, error)
+	AcknowledgeAlert(ctx context.Context, id uuid.UUID, userID *uuid.UUID) (*models.Alert, error)
+	CreateWebhook(ctx context.Context, subscription models.WebhookSubscription) (*models.WebhookSubscription, error)
+	GetWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error)
+	GetWebhookByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, id uuid.UUID, req dto.UpdateWebhookRequest) (*models.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	GetWebhookDeliveries(
+		ctx context.Context,
+		subscriptionID uuid.UUID,
+		req dto.GetWebhookDeliveriesRequest,
+	) (*models.WebhookDeliveryPage, error)
+	ListenHistory(ctx context.Context, onChange func(historyID uuid.UUID)) error
+	GetHistoryByID(ctx context.Context, id uuid.UUID) (*models.History, error)
+	GetHistoryChain(ctx context.Context, afterSeq int64, limit int) ([]*models.HistoryChainLink, error)
+	GetLatestHistoryCheckpoint(ctx context.Context) (*models.HistoryChainAnchor, error)
+	SaveHistoryCheckpoint(ctx context.Context, checkpoint models.HistoryChainAnchor) error
+	FanOutWebhookEvents(ctx context.Context, limit int) (int64, error)
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.PendingWebhookDelivery, error)
+	MarkWebhookDelivered(ctx context.Context, id uuid.UUID, responseStatus int) error
+	MarkWebhookAttemptFailed(
+		ctx context.Context,
+		id uuid.UUID,
+		responseStatus *int,
+		lastError string,
+		status string,
+		nextAttemptAt time.Time,
+	) error
+	DeleteProcessedWebhookEvents(ctx context.Context, before time.Time) (int64, error)
 }
 
 type Repository struct {
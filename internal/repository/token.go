@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gookit/slog"
+	"github.com/jackc/pgx/v5"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+)
+
+func (r *Repository) CreateRefreshToken(ctx context.Context, token models.RefreshToken) error {
+	if _, err := r.conn.Exec(ctx, queries.CreateRefreshTokenQuery,
+		token.ID,
+		token.UserID,
+		token.FamilyID,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("Exec-CreateRefreshToken: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RotateRefreshToken(
+	ctx context.Context,
+	tokenHash string,
+	next models.RefreshToken,
+) (*models.RefreshToken, error) {
+	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("BeginTx-RotateRefreshToken: %w", err)
+	}
+
+	defer func() {
+		rbErr := tx.Rollback(context.Background())
+		if rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			slog.Errorf("Rollback-RotateRefreshToken: %v", rbErr)
+		}
+	}()
+
+	current := new(models.RefreshToken)
+	if err = tx.QueryRow(ctx, queries.GetRefreshTokenForUpdateQuery, tokenHash).Scan(
+		&current.ID,
+		&current.UserID,
+		&current.FamilyID,
+		&current.TokenHash,
+		&current.ExpiresAt,
+		&current.CreatedAt,
+		&current.RevokedAt,
+		&current.ReplacedBy,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("QueryRow-RotateRefreshToken: %w", err)
+	}
+
+	if current.RevokedAt != nil {
+		if _, err = tx.Exec(ctx, queries.RevokeRefreshTokenFamilyQuery, current.FamilyID); err != nil {
+			return nil, fmt.Errorf("Exec-RevokeRefreshTokenFamily: %w", err)
+		}
+		if err = tx.Commit(context.Background()); err != nil {
+			return nil, fmt.Errorf("Commit-RotateRefreshToken: %w", err)
+		}
+		return nil, apperrors.ErrRefreshTokenReused
+	}
+
+	if !current.ExpiresAt.After(time.Now()) {
+		return nil, apperrors.ErrInvalidRefreshToken
+	}
+
+	next.UserID = current.UserID
+	next.FamilyID = current.FamilyID
+	if _, err = tx.Exec(ctx, queries.CreateRefreshTokenQuery,
+		next.ID,
+		next.UserID,
+		next.FamilyID,
+		next.TokenHash,
+		next.ExpiresAt,
+		next.CreatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("Exec-CreateRefreshToken: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, queries.ReplaceRefreshTokenQuery, current.ID, next.ID); err != nil {
+		return nil, fmt.Errorf("Exec-ReplaceRefreshToken: %w", err)
+	}
+
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, fmt.Errorf("Commit-RotateRefreshToken: %w", err)
+	}
+
+	return &next, nil
+}
+
+func (r *Repository) RevokeRefreshToken(ctx context.Context, tokenHash string, userID uuid.UUID) error {
+	if _, err := r.conn.Exec(ctx, queries.RevokeRefreshTokenQuery, tokenHash, userID); err != nil {
+		return fmt.Errorf("Exec-RevokeRefreshToken: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RevokeAccessToken(ctx context.Context, jti, userID uuid.UUID, expiresAt time.Time) error {
+	if _, err := r.conn.Exec(ctx, queries.RevokeAccessTokenQuery, jti, userID, expiresAt); err != nil {
+		return fmt.Errorf("Exec-RevokeAccessToken: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) IsTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error) {
+	var revoked bool
+	if err := r.conn.QueryRow(ctx, queries.IsTokenRevokedQuery, jti).Scan(&revoked); err != nil {
+		return false, fmt.Errorf("QueryRow-IsTokenRevoked: %w", err)
+	}
+
+	return revoked, nil
+}
+
+func (r *Repository) DeleteExpiredTokens(ctx context.Context) (int64, error) {
+	refresh, err := r.conn.Exec(ctx, queries.DeleteExpiredRefreshTokensQuery)
+	if err != nil {
+		return 0, fmt.Errorf("Exec-DeleteExpiredRefreshTokens: %w", err)
+	}
+
+	revoked, err := r.conn.Exec(ctx, queries.DeleteExpiredRevokedTokensQuery)
+	if err != nil {
+		return 0, fmt.Errorf("Exec-DeleteExpiredRevokedTokens: %w", err)
+	}
+
+	return refresh.RowsAffected() + revoked.RowsAffected(), nil
+}
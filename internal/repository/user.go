@@ -2,60 +2,309 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
+	"github.com/gookit/slog"
 	"github.com/jackc/pgx/v5"
 	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
 	"github.com/kstsm/wb-warehouse-control/internal/models"
 	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
 )
 
-func (r *Repository) GetOrCreateUser(ctx context.Context, user models.User) (*models.User, error) {
-	var resp models.User
+func (r *Repository) CreateUser(ctx context.Context, user models.User, actorID *uuid.UUID) (*models.User, error) {
+	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("BeginTx-CreateUser: %w", err)
+	}
+
+	defer func() {
+		rbErr := tx.Rollback(context.Background())
+		if rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			slog.Errorf("Rollback-CreateUser: %v", rbErr)
+		}
+	}()
+
+	if errSetUser := setUserIDInTx(ctx, tx, actorID); errSetUser != nil {
+		return nil, fmt.Errorf("setUserIDInTx-CreateUser: %w", errSetUser)
+	}
+
+	if errSet := setChangeContextInTx(ctx, tx, dto.ChangeReason{}); errSet != nil {
+		return nil, fmt.Errorf("setChangeContextInTx-CreateUser: %w", errSet)
+	}
 
-	err := r.conn.QueryRow(ctx, queries.GetOrCreateUserQuery,
+	created, err := r.scanUser(tx.QueryRow(ctx, queries.CreateUserQuery,
 		user.ID,
 		user.Name,
 		user.Role,
+		user.PasswordHash,
 		user.CreatedAt,
 		user.UpdatedAt,
-	).Scan(
-		&resp.ID,
-		&resp.Name,
-		&resp.Role,
-		&resp.CreatedAt,
-		&resp.UpdatedAt,
-	)
+	))
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("QueryRow-GetOrCreateUser: unexpected: no user returned")
+		if isUniqueViolation(err) {
+			return nil, apperrors.ErrUserAlreadyExists
+		}
+		return nil, fmt.Errorf("QueryRow-CreateUser: %w", err)
+	}
+
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, fmt.Errorf("Commit-CreateUser: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *Repository) GetUsers(ctx context.Context) ([]*models.User, error) {
+	rows, err := r.conn.Query(ctx, queries.GetUsersQuery)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetUsers: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		user, errScan := r.scanUser(rows)
+		if errScan != nil {
+			return nil, fmt.Errorf("Scan-GetUsers: %w", errScan)
 		}
-		return nil, fmt.Errorf("QueryRow-GetOrCreateUser: %w", err)
+		users = append(users, user)
 	}
 
-	if resp.Role != user.Role {
-		return nil, apperrors.ErrRoleMismatch
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetUsers rows.Err: %w", errRows)
 	}
 
-	return &resp, nil
+	return users, nil
+}
+
+func (r *Repository) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	user, err := r.scanUser(r.conn.QueryRow(ctx, queries.GetUserByIDQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-GetUserByID: %w", err)
+	}
+
+	return user, nil
 }
 
 func (r *Repository) GetUserByName(ctx context.Context, userName string) (*models.User, error) {
+	user, err := r.scanUser(r.conn.QueryRow(ctx, queries.GetUserByNameQuery, userName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-GetUserByName: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *Repository) UpdateUser(
+	ctx context.Context,
+	id uuid.UUID,
+	update models.UserUpdate,
+	reason dto.ChangeReason,
+	actorID *uuid.UUID,
+) (*models.User, error) {
+	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("BeginTx-UpdateUser: %w", err)
+	}
+
+	defer func() {
+		rbErr := tx.Rollback(context.Background())
+		if rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			slog.Errorf("Rollback-UpdateUser: %v", rbErr)
+		}
+	}()
+
+	if errSetUser := setUserIDInTx(ctx, tx, actorID); errSetUser != nil {
+		return nil, fmt.Errorf("setUserIDInTx-UpdateUser: %w", errSetUser)
+	}
+
+	if errSet := setChangeContextInTx(ctx, tx, reason); errSet != nil {
+		return nil, fmt.Errorf("setChangeContextInTx-UpdateUser: %w", errSet)
+	}
+
+	demotes := update.Role != nil || (update.Disabled != nil && *update.Disabled)
+	if demotes {
+		if err = lockActiveAdminsInTx(ctx, tx); err != nil {
+			return nil, fmt.Errorf("lockActiveAdminsInTx-UpdateUser: %w", err)
+		}
+	}
+
+	updated, err := r.scanUser(tx.QueryRow(ctx, queries.UpdateUserQuery,
+		id,
+		update.Role,
+		update.PasswordHash,
+		update.Disabled,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-UpdateUser: %w", err)
+	}
+
+	if demotes {
+		if err = ensureActiveAdminInTx(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	if (update.Disabled != nil && *update.Disabled) || update.PasswordHash != nil {
+		if _, err = tx.Exec(ctx, queries.RevokeUserRefreshTokensQuery, id); err != nil {
+			return nil, fmt.Errorf("Exec-RevokeUserRefreshTokens: %w", err)
+		}
+	}
+
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, fmt.Errorf("Commit-UpdateUser: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *Repository) DeleteUser(ctx context.Context, id uuid.UUID, reason dto.ChangeReason, actorID *uuid.UUID) error {
+	tx, err := r.conn.BeginTx(context.Background(), pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("BeginTx-DeleteUser: %w", err)
+	}
+
+	defer func() {
+		rbErr := tx.Rollback(context.Background())
+		if rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			slog.Errorf("Rollback-DeleteUser: %v", rbErr)
+		}
+	}()
+
+	if errSetUser := setUserIDInTx(ctx, tx, actorID); errSetUser != nil {
+		return fmt.Errorf("setUserIDInTx-DeleteUser: %w", errSetUser)
+	}
+
+	if errSet := setChangeContextInTx(ctx, tx, reason); errSet != nil {
+		return fmt.Errorf("setChangeContextInTx-DeleteUser: %w", errSet)
+	}
+
+	if err = lockActiveAdminsInTx(ctx, tx); err != nil {
+		return fmt.Errorf("lockActiveAdminsInTx-DeleteUser: %w", err)
+	}
+
+	var deletedID uuid.UUID
+	if err = tx.QueryRow(ctx, queries.DeleteUserQuery, id).Scan(&deletedID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.ErrUserNotFound
+		}
+		return fmt.Errorf("QueryRow-DeleteUser: %w", err)
+	}
+
+	if err = ensureActiveAdminInTx(ctx, tx); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, queries.RevokeUserRefreshTokensQuery, deletedID); err != nil {
+		return fmt.Errorf("Exec-RevokeUserRefreshTokens: %w", err)
+	}
+
+	if err = tx.Commit(context.Background()); err != nil {
+		return fmt.Errorf("Commit-DeleteUser: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetUserHistory(ctx context.Context, userID uuid.UUID) ([]*models.UserHistory, error) {
+	rows, err := r.conn.Query(ctx, queries.GetUserHistoryQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetUserHistory: %w", err)
+	}
+	defer rows.Close()
+
+	var history []*models.UserHistory
+	for rows.Next() {
+		entry := new(models.UserHistory)
+		var oldDataJSON, newDataJSON []byte
+
+		if errScan := rows.Scan(
+			&entry.ID,
+			&entry.UserID,
+			&entry.Action,
+			&entry.ChangedBy,
+			&entry.ChangedAt,
+			&oldDataJSON,
+			&newDataJSON,
+			&entry.ReasonCode,
+			&entry.Reason,
+			&entry.RequestID,
+			&entry.APIKeyID,
+		); errScan != nil {
+			return nil, fmt.Errorf("Scan-GetUserHistory: %w", errScan)
+		}
+
+		if len(oldDataJSON) > 0 {
+			if errUnmarshal := json.Unmarshal(oldDataJSON, &entry.OldData); errUnmarshal != nil {
+				return nil, fmt.Errorf("GetUserHistory unmarshal oldData: %w", errUnmarshal)
+			}
+		}
+
+		if len(newDataJSON) > 0 {
+			if errUnmarshal := json.Unmarshal(newDataJSON, &entry.NewData); errUnmarshal != nil {
+				return nil, fmt.Errorf("GetUserHistory unmarshal newData: %w", errUnmarshal)
+			}
+		}
+
+		history = append(history, entry)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetUserHistory rows.Err: %w", errRows)
+	}
+
+	return history, nil
+}
+
+func (r *Repository) scanUser(row pgx.Row) (*models.User, error) {
 	user := new(models.User)
-	err := r.conn.QueryRow(ctx, queries.GetUserByNameQuery, userName).Scan(
+	if err := row.Scan(
 		&user.ID,
 		&user.Name,
 		&user.Role,
+		&user.PasswordHash,
+		&user.DisabledAt,
+		&user.DeletedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
-	)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, apperrors.ErrUserNotFound
-		}
-		return nil, fmt.Errorf("QueryRow-GetUserByName: %w", err)
+	); err != nil {
+		return nil, err
 	}
 
 	return user, nil
 }
+
+func lockActiveAdminsInTx(ctx context.Context, tx pgx.Tx) error {
+	var count int
+	if err := tx.QueryRow(ctx, queries.LockActiveAdminsQuery).Scan(&count); err != nil {
+		return fmt.Errorf("QueryRow-LockActiveAdmins: %w", err)
+	}
+
+	return nil
+}
+
+func ensureActiveAdminInTx(ctx context.Context, tx pgx.Tx) error {
+	var count int
+	if err := tx.QueryRow(ctx, queries.CountActiveAdminsQuery).Scan(&count); err != nil {
+		return fmt.Errorf("QueryRow-CountActiveAdmins: %w", err)
+	}
+
+	if count == 0 {
+		return apperrors.ErrLastAdmin
+	}
+
+	return nil
+}
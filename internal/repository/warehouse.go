@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+)
+
+func (r *Repository) CreateWarehouse(ctx context.Context, warehouse models.Warehouse) (*models.Warehouse, error) {
+	created, err := r.scanWarehouse(r.conn.QueryRow(ctx, queries.CreateWarehouseQuery,
+		warehouse.ID,
+		warehouse.Code,
+		warehouse.Name,
+		warehouse.Address,
+		warehouse.CreatedAt,
+		warehouse.UpdatedAt,
+	))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, apperrors.ErrWarehouseExists
+		}
+		return nil, fmt.Errorf("QueryRow-CreateWarehouse: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *Repository) GetWarehouses(ctx context.Context) ([]*models.Warehouse, error) {
+	rows, err := r.conn.Query(ctx, queries.GetWarehousesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetWarehouses: %w", err)
+	}
+	defer rows.Close()
+
+	var warehouses []*models.Warehouse
+	for rows.Next() {
+		warehouse, errScan := r.scanWarehouse(rows)
+		if errScan != nil {
+			return nil, fmt.Errorf("Scan-GetWarehouses: %w", errScan)
+		}
+		warehouses = append(warehouses, warehouse)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetWarehouses rows.Err: %w", errRows)
+	}
+
+	return warehouses, nil
+}
+
+func (r *Repository) GetWarehouseByID(ctx context.Context, id uuid.UUID) (*models.Warehouse, error) {
+	warehouse, err := r.scanWarehouse(r.conn.QueryRow(ctx, queries.GetWarehouseByIDQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrWarehouseNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-GetWarehouseByID: %w", err)
+	}
+
+	return warehouse, nil
+}
+
+func (r *Repository) GetItemStocks(ctx context.Context, itemID uuid.UUID) ([]models.ItemStock, error) {
+	return r.queryItemStocks(ctx, r.conn, itemID)
+}
+
+type rowQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func (r *Repository) queryItemStocks(ctx context.Context, q rowQuerier, itemID uuid.UUID) ([]models.ItemStock, error) {
+	rows, err := q.Query(ctx, queries.GetItemStocksQuery, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetItemStocks: %w", err)
+	}
+	defer rows.Close()
+
+	stocks := make([]models.ItemStock, 0)
+	for rows.Next() {
+		var stock models.ItemStock
+		if errScan := rows.Scan(
+			&stock.WarehouseID,
+			&stock.WarehouseCode,
+			&stock.WarehouseName,
+			&stock.Quantity,
+		); errScan != nil {
+			return nil, fmt.Errorf("Scan-GetItemStocks: %w", errScan)
+		}
+		stocks = append(stocks, stock)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetItemStocks rows.Err: %w", errRows)
+	}
+
+	return stocks, nil
+}
+
+func (r *Repository) scanWarehouse(row pgx.Row) (*models.Warehouse, error) {
+	warehouse := new(models.Warehouse)
+	if err := row.Scan(
+		&warehouse.ID,
+		&warehouse.Code,
+		&warehouse.Name,
+		&warehouse.Address,
+		&warehouse.IsDefault,
+		&warehouse.CreatedAt,
+		&warehouse.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return warehouse, nil
+}
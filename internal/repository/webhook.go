@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository/queries"
+)
+
+func enqueueWebhookEventInTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	eventType string,
+	itemID uuid.UUID,
+	userID *uuid.UUID,
+) error {
+	if _, err := tx.Exec(ctx, queries.EnqueueWebhookEventQuery, uuid.New(), eventType, itemID, userID); err != nil {
+		return fmt.Errorf("enqueueWebhookEventInTx %s: %w", eventType, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) CreateWebhook(
+	ctx context.Context,
+	subscription models.WebhookSubscription,
+) (*models.WebhookSubscription, error) {
+	created, err := r.scanWebhook(r.conn.QueryRow(ctx, queries.CreateWebhookQuery,
+		subscription.ID,
+		subscription.URL,
+		subscription.Events,
+		subscription.Secret,
+		subscription.IsActive,
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("QueryRow-CreateWebhook: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *Repository) GetWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	rows, err := r.conn.Query(ctx, queries.GetWebhooksQuery)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetWebhooks: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := make([]*models.WebhookSubscription, 0)
+	for rows.Next() {
+		subscription, errScan := r.scanWebhook(rows)
+		if errScan != nil {
+			return nil, fmt.Errorf("Scan-GetWebhooks: %w", errScan)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetWebhooks rows.Err: %w", errRows)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *Repository) GetWebhookByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	subscription, err := r.scanWebhook(r.conn.QueryRow(ctx, queries.GetWebhookByIDQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-GetWebhookByID: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (r *Repository) UpdateWebhook(
+	ctx context.Context,
+	id uuid.UUID,
+	req dto.UpdateWebhookRequest,
+) (*models.WebhookSubscription, error) {
+	subscription, err := r.scanWebhook(r.conn.QueryRow(ctx, queries.UpdateWebhookQuery,
+		id,
+		req.URL,
+		req.Events,
+		req.Secret,
+		req.IsActive,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("QueryRow-UpdateWebhook: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (r *Repository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.conn.Exec(ctx, queries.DeleteWebhookQuery, id)
+	if err != nil {
+		return fmt.Errorf("Exec-DeleteWebhook: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) GetWebhookDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	req dto.GetWebhookDeliveriesRequest,
+) (*models.WebhookDeliveryPage, error) {
+	page := &models.WebhookDeliveryPage{Deliveries: make([]*models.WebhookDelivery, 0)}
+
+	if err := r.conn.QueryRow(ctx, queries.GetWebhookDeliveriesCountQuery,
+		subscriptionID,
+		req.Status,
+	).Scan(&page.Total); err != nil {
+		return nil, fmt.Errorf("QueryRow-GetWebhookDeliveriesCount: %w", err)
+	}
+
+	rows, err := r.conn.Query(ctx, queries.GetWebhookDeliveriesQuery, subscriptionID, req.Status, req.Limit, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("Query-GetWebhookDeliveries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		delivery := new(models.WebhookDelivery)
+		if errScan := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.ItemID,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.ResponseStatus,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.DeliveredAt,
+		); errScan != nil {
+			return nil, fmt.Errorf("Scan-GetWebhookDeliveries: %w", errScan)
+		}
+		page.Deliveries = append(page.Deliveries, delivery)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("GetWebhookDeliveries rows.Err: %w", errRows)
+	}
+
+	return page, nil
+}
+
+func (r *Repository) FanOutWebhookEvents(ctx context.Context, limit int) (int64, error) {
+	tag, err := r.conn.Exec(ctx, queries.FanOutWebhookEventsQuery, limit)
+	if err != nil {
+		return 0, fmt.Errorf("Exec-FanOutWebhookEvents: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *Repository) ClaimWebhookDeliveries(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]*models.PendingWebhookDelivery, error) {
+	rows, err := r.conn.Query(ctx, queries.ClaimWebhookDeliveriesQuery, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("Query-ClaimWebhookDeliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*models.PendingWebhookDelivery
+	for rows.Next() {
+		delivery := new(models.PendingWebhookDelivery)
+		if errScan := rows.Scan(
+			&delivery.ID,
+			&delivery.Attempts,
+			&delivery.URL,
+			&delivery.Secret,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.ItemID,
+			&delivery.UserID,
+			&delivery.APIKeyID,
+			&delivery.Payload,
+			&delivery.OccurredAt,
+		); errScan != nil {
+			return nil, fmt.Errorf("Scan-ClaimWebhookDeliveries: %w", errScan)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, fmt.Errorf("ClaimWebhookDeliveries rows.Err: %w", errRows)
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repository) MarkWebhookDelivered(ctx context.Context, id uuid.UUID, responseStatus int) error {
+	if _, err := r.conn.Exec(ctx, queries.MarkWebhookDeliveredQuery, id, responseStatus); err != nil {
+		return fmt.Errorf("Exec-MarkWebhookDelivered: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkWebhookAttemptFailed(
+	ctx context.Context,
+	id uuid.UUID,
+	responseStatus *int,
+	lastError string,
+	status string,
+	nextAttemptAt time.Time,
+) error {
+	if _, err := r.conn.Exec(ctx, queries.MarkWebhookAttemptFailedQuery,
+		id,
+		responseStatus,
+		lastError,
+		status,
+		nextAttemptAt,
+	); err != nil {
+		return fmt.Errorf("Exec-MarkWebhookAttemptFailed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteProcessedWebhookEvents(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.conn.Exec(ctx, queries.DeleteProcessedWebhookEventsQuery, before)
+	if err != nil {
+		return 0, fmt.Errorf("Exec-DeleteProcessedWebhookEvents: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *Repository) scanWebhook(row pgx.Row) (*models.WebhookSubscription, error) {
+	subscription := new(models.WebhookSubscription)
+	if err := row.Scan(
+		&subscription.ID,
+		&subscription.URL,
+		&subscription.Events,
+		&subscription.Secret,
+		&subscription.IsActive,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func (s *Service) GetLowStockItems(ctx context.Context, req dto.GetLowStockItemsRequest) ([]*models.Item, int, error) {
+	return s.repo.GetLowStockItems(ctx, req)
+}
+
+func (s *Service) GetAlerts(ctx context.Context, req dto.GetAlertsRequest) ([]*models.Alert, int, error) {
+	return s.repo.GetAlerts(ctx, req)
+}
+
+func (s *Service) AcknowledgeAlert(ctx context.Context, id uuid.UUID, userID *uuid.UUID) (*models.Alert, error) {
+	return s.repo.AcknowledgeAlert(ctx, id, userID)
+}
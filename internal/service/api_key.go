@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/pkg/apikey"
+	"github.com/kstsm/wb-warehouse-control/pkg/secret"
+)
+
+func (s *Service) CreateAPIKey(
+	ctx context.Context,
+	req dto.CreateAPIKeyRequest,
+	actorID *uuid.UUID,
+) (*models.APIKey, string, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, "", apperrors.ErrAPIKeyExpiresInPast
+	}
+
+	raw, err := secret.Generate(apikey.Prefix)
+	if err != nil {
+		return nil, "", err
+	}
+
+	key, err := s.repo.CreateAPIKey(ctx, models.APIKey{
+		ID:        uuid.New(),
+		Name:      req.Name,
+		Role:      req.Role,
+		KeyPrefix: raw[:apikey.PrefixLength],
+		KeyHash:   secret.Hash(raw),
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: actorID,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, raw, nil
+}
+
+func (s *Service) GetAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	return s.repo.GetAPIKeys(ctx)
+}
+
+func (s *Service) GetAPIKeyByID(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	return s.repo.GetAPIKeyByID(ctx, id)
+}
+
+func (s *Service) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	return s.repo.RevokeAPIKey(ctx, id)
+}
+
+func (s *Service) AuthenticateAPIKey(ctx context.Context, raw string) (*models.APIKey, error) {
+	if !strings.HasPrefix(raw, apikey.Prefix) {
+		return nil, apperrors.ErrInvalidAPIKey
+	}
+
+	return s.repo.TouchAPIKey(ctx, secret.Hash(raw))
+}
@@ -2,32 +2,206 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
 	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository"
 	"github.com/kstsm/wb-warehouse-control/pkg/jwt"
+	"github.com/kstsm/wb-warehouse-control/pkg/password"
+	"github.com/kstsm/wb-warehouse-control/pkg/secret"
 )
 
-func (s *Service) SignInOrSignUp(ctx context.Context, userName, role string) (string, string, error) {
+type Accounts struct {
+	repo      repository.ItemManager
+	passwords *password.Hasher
+}
+
+func NewAccounts(repo repository.ItemManager, bcryptCost int) (*Accounts, error) {
+	passwords, err := password.NewHasher(bcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Accounts{
+		repo:      repo,
+		passwords: passwords,
+	}, nil
+}
+
+func (a *Accounts) Create(
+	ctx context.Context,
+	userName, plain, role string,
+	actorID *uuid.UUID,
+) (*models.User, error) {
+	hash, err := a.HashPassword(plain)
+	if err != nil {
+		return nil, err
+	}
+
 	user := models.User{
-		ID:        uuid.New(),
-		Name:      userName,
-		Role:      role,
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
+		ID:           uuid.New(),
+		Name:         userName,
+		Role:         role,
+		PasswordHash: &hash,
+		CreatedAt:    time.Now().UTC(),
+		UpdatedAt:    time.Now().UTC(),
+	}
+
+	return a.repo.CreateUser(ctx, user, actorID)
+}
+
+func (a *Accounts) Authenticate(ctx context.Context, userName, plain string) (*models.User, error) {
+	user, err := a.repo.GetUserByName(ctx, userName)
+	if err != nil && !errors.Is(err, apperrors.ErrUserNotFound) {
+		return nil, err
+	}
+
+	var hash *string
+	if user != nil {
+		hash = user.PasswordHash
+	}
+
+	if errCompare := a.passwords.Compare(hash, plain); errCompare != nil || user.DeletedAt != nil {
+		return nil, apperrors.ErrInvalidCredentials
 	}
 
-	resultUser, err := s.repo.GetOrCreateUser(ctx, user)
+	if user.DisabledAt != nil {
+		return nil, apperrors.ErrUserDisabled
+	}
+
+	return user, nil
+}
+
+func (a *Accounts) HashPassword(plain string) (string, error) {
+	hash, err := a.passwords.Hash(plain)
+	if err != nil {
+		if errors.Is(err, password.ErrTooLong) {
+			return "", apperrors.ErrPasswordTooLong
+		}
+		return "", err
+	}
+
+	return hash, nil
+}
+
+func (s *Service) SignIn(ctx context.Context, req dto.LoginRequest) (*models.AuthTokens, error) {
+	user, err := s.accounts.Authenticate(ctx, req.UserName, req.Password)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
-	jwtRole := jwt.Role(resultUser.Role)
-	token, err := s.tokenGenerator.GenerateToken(resultUser.ID, jwtRole)
+	refreshToken, err := newRefreshToken(s.auth.RefreshTTL)
 	if err != nil {
-		return "", "", err
+		return nil, err
+	}
+	refreshToken.token.UserID = user.ID
+	refreshToken.token.FamilyID = uuid.New()
+
+	if err = s.repo.CreateRefreshToken(ctx, refreshToken.token); err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(user, refreshToken.raw)
+}
+
+func (s *Service) RefreshTokens(ctx context.Context, req dto.RefreshTokenRequest) (*models.AuthTokens, error) {
+	refreshToken, err := newRefreshToken(s.auth.RefreshTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := s.repo.RotateRefreshToken(ctx, secret.Hash(req.RefreshToken), refreshToken.token)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRefreshTokenReused) {
+			s.log.WithCtx(ctx).Warnf("Refresh token reuse detected, token family revoked")
+			return nil, apperrors.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	user, err := s.GetActiveUser(ctx, rotated.UserID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) || errors.Is(err, apperrors.ErrUserDisabled) {
+			return nil, apperrors.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	return s.issueTokens(user, refreshToken.raw)
+}
+
+func (s *Service) Logout(ctx context.Context, claims *jwt.Claims, req dto.LogoutRequest) error {
+	jti, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return fmt.Errorf("Logout: invalid jti: %w", err)
+	}
+
+	expiresAt := time.Now().Add(s.tokenGenerator.TTL())
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	if err = s.repo.RevokeAccessToken(ctx, jti, claims.UserID, expiresAt); err != nil {
+		return err
+	}
+
+	if req.RefreshToken != nil {
+		return s.repo.RevokeRefreshToken(ctx, secret.Hash(*req.RefreshToken), claims.UserID)
+	}
+
+	return nil
+}
+
+func (s *Service) IsTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error) {
+	return s.repo.IsTokenRevoked(ctx, jti)
+}
+
+func (s *Service) issueTokens(user *models.User, refreshToken string) (*models.AuthTokens, error) {
+	accessToken, err := s.tokenGenerator.GenerateToken(user.ID, jwt.Role(user.Role))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthTokens{
+		AccessToken:  accessToken,
+		ExpiresIn:    s.tokenGenerator.TTL(),
+		RefreshToken: refreshToken,
+		Role:         user.Role,
+	}, nil
+}
+
+type issuedRefreshToken struct {
+	raw   string
+	token models.RefreshToken
+}
+
+func newRefreshToken(ttl time.Duration) (*issuedRefreshToken, error) {
+	raw, err := secret.Generate("")
+	if err != nil {
+		return nil, fmt.Errorf("newRefreshToken: %w", err)
+	}
+
+	now := time.Now().UTC()
+	return &issuedRefreshToken{
+		raw: raw,
+		token: models.RefreshToken{
+			ID:        uuid.New(),
+			TokenHash: secret.Hash(raw),
+			ExpiresAt: now.Add(ttl),
+			CreatedAt: now,
+		},
+	}, nil
+}
+
+func (s *Service) Register(ctx context.Context, req dto.RegisterRequest) (*models.User, error) {
+	if !s.auth.SelfRegistration {
+		return nil, apperrors.ErrRegistrationDisabled
 	}
 
-	return token, resultUser.Role, nil
+	return s.accounts.Create(ctx, req.UserName, req.Password, string(jwt.RoleViewer), nil)
 }
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func (s *Service) CreateCategory(ctx context.Context, req dto.CreateCategoryRequest) (*models.Category, error) {
+	category := models.Category{
+		ID:        uuid.New(),
+		Name:      req.Name,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	if req.ParentID != nil {
+		parentID := uuid.MustParse(*req.ParentID)
+		category.ParentID = &parentID
+	}
+
+	return s.repo.CreateCategory(ctx, category)
+}
+
+func (s *Service) GetCategories(ctx context.Context) ([]*models.Category, error) {
+	return s.repo.GetCategories(ctx)
+}
+
+func (s *Service) GetCategoryByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	return s.repo.GetCategoryByID(ctx, id)
+}
+
+func (s *Service) UpdateCategory(
+	ctx context.Context,
+	id uuid.UUID,
+	req dto.UpdateCategoryRequest,
+) (*models.Category, error) {
+	return s.repo.UpdateCategory(ctx, id, req)
+}
+
+func (s *Service) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteCategory(ctx, id)
+}
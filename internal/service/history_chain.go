@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository"
+	"github.com/kstsm/wb-warehouse-control/pkg/hashchain"
+)
+
+const historyChainBatchSize = 1000
+
+type HistoryVerifier struct {
+	repo repository.ItemManager
+}
+
+func NewHistoryVerifier(repo repository.ItemManager) *HistoryVerifier {
+	return &HistoryVerifier{repo: repo}
+}
+
+func (v *HistoryVerifier) Verify(
+	ctx context.Context,
+	expected *models.HistoryChainAnchor,
+) (*models.HistoryVerification, error) {
+	checkpoint, err := v.repo.GetLatestHistoryCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := v.walk(ctx, chainAnchors(expected, checkpoint))
+	if err != nil || result.Broken != nil || result.HeadSeq == nil {
+		return result, err
+	}
+
+	head := models.HistoryChainAnchor{ChainSeq: *result.HeadSeq, Hash: *result.HeadHash}
+	if err = v.repo.SaveHistoryCheckpoint(ctx, head); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (v *HistoryVerifier) walk(
+	ctx context.Context,
+	anchors []models.HistoryChainAnchor,
+) (*models.HistoryVerification, error) {
+	result := new(models.HistoryVerification)
+
+	var afterSeq int64
+	for {
+		links, err := v.repo.GetHistoryChain(ctx, afterSeq, historyChainBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, link := range links {
+			reason := checkChainLink(link, result.HeadHash)
+			if reason == "" {
+				reason, anchors = checkChainAnchors(link, anchors)
+			}
+			if reason != "" {
+				result.Broken = &models.HistoryChainBreak{
+					HistoryID: link.ID,
+					ChainSeq:  link.ChainSeq,
+					ChangedAt: link.ChangedAt,
+					Reason:    reason,
+				}
+				return result, nil
+			}
+
+			result.Verified++
+			result.HeadSeq = &link.ChainSeq
+			result.HeadHash = &link.Hash
+			afterSeq = link.ChainSeq
+		}
+
+		if len(links) < historyChainBatchSize {
+			break
+		}
+	}
+
+	if len(anchors) > 0 {
+		result.Broken = &models.HistoryChainBreak{
+			ChainSeq: anchors[0].ChainSeq,
+			Reason:   models.HistoryChainAnchorMissing,
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) VerifyHistory(ctx context.Context, req dto.VerifyHistoryRequest) (*models.HistoryVerification, error) {
+	var expected *models.HistoryChainAnchor
+	if req.HeadSeq != nil && req.HeadHash != nil {
+		expected = &models.HistoryChainAnchor{ChainSeq: *req.HeadSeq, Hash: *req.HeadHash}
+	}
+
+	return NewHistoryVerifier(s.repo).Verify(ctx, expected)
+}
+
+func chainAnchors(anchors ...*models.HistoryChainAnchor) []models.HistoryChainAnchor {
+	var sorted []models.HistoryChainAnchor
+	for _, anchor := range anchors {
+		if anchor != nil {
+			sorted = append(sorted, *anchor)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ChainSeq < sorted[j].ChainSeq
+	})
+
+	return sorted
+}
+
+func checkChainAnchors(
+	link *models.HistoryChainLink,
+	anchors []models.HistoryChainAnchor,
+) (string, []models.HistoryChainAnchor) {
+	for len(anchors) > 0 && anchors[0].ChainSeq <= link.ChainSeq {
+		switch {
+		case anchors[0].ChainSeq < link.ChainSeq:
+			return models.HistoryChainAnchorMissing, anchors
+		case anchors[0].Hash != link.Hash:
+			return models.HistoryChainAnchorMismatch, anchors
+		}
+		anchors = anchors[1:]
+	}
+
+	return "", anchors
+}
+
+func checkChainLink(link *models.HistoryChainLink, prevHash *string) string {
+	if chainLinkHash(link) != link.Hash {
+		return models.HistoryChainHashMismatch
+	}
+
+	if !sameHash(link.PrevHash, prevHash) {
+		return models.HistoryChainPrevHashMismatch
+	}
+
+	return ""
+}
+
+func chainLinkHash(link *models.HistoryChainLink) string {
+	var quantityDelta *string
+	if link.QuantityDelta != nil {
+		delta := strconv.Itoa(*link.QuantityDelta)
+		quantityDelta = &delta
+	}
+
+	fields := []*string{
+		chainText(strconv.FormatInt(link.ChainSeq, 10)),
+		link.PrevHash,
+		chainText(link.ID.String()),
+		chainText(link.ItemID.String()),
+		chainText(link.Action),
+		chainUUID(link.UserID),
+		chainText(strconv.FormatInt(link.ChangedAt.UnixMicro(), 10)),
+		link.OldData,
+		link.NewData,
+		quantityDelta,
+		link.ReasonCode,
+		link.Reference,
+		chainUUID(link.WarehouseID),
+		chainUUID(link.LocationID),
+		chainUUID(link.RevertedFrom),
+		link.Reason,
+		link.RequestID,
+	}
+
+	if link.APIKeyID != nil {
+		fields = append(fields, chainUUID(link.APIKeyID))
+	}
+
+	return hashchain.Hash(fields...)
+}
+
+func chainText(value string) *string {
+	return &value
+}
+
+func chainUUID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	return chainText(id.String())
+}
+
+func sameHash(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
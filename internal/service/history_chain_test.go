@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository"
+)
+
+const (
+	chainRowUpdateHash = "7f02a4b45720236c4daa0e85f5706d0d4fa564c44c89fa81bcb71d3b5a31ef96"
+	chainRowAPIKeyHash = "a64230aff4a495199890168dea5659091d0a1f91a75b9e5ed8427ed52c420bcd"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func uuidPtr(s string) *uuid.UUID {
+	id := uuid.MustParse(s)
+	return &id
+}
+
+func chainRowUpdate() *models.HistoryChainLink {
+	return &models.HistoryChainLink{
+		ChainSeq:   41,
+		ID:         uuid.MustParse("b2c3d4e5-f6a7-8901-bcde-f12345678901"),
+		ItemID:     uuid.MustParse("b9ab5b36-444a-47c4-b7b1-7067a4977e67"),
+		Action:     "update",
+		UserID:     uuidPtr("550e8400-e29b-41d4-a716-446655440000"),
+		ChangedAt:  time.UnixMicro(1765311330123456),
+		OldData:    strPtr(`{"price": 15000000, "quantity": 10}`),
+		NewData:    strPtr(`{"price": 16000000, "quantity": 15}`),
+		ReasonCode: strPtr("price_change"),
+		Reason:     strPtr("Новый прайс поставщика"),
+		RequestID:  strPtr("5d2b7c1e-8f4a-4b6e-9c3d-1a2b3c4d5e6f"),
+		Hash:       chainRowUpdateHash,
+	}
+}
+
+func chainRowAPIKey() *models.HistoryChainLink {
+	return &models.HistoryChainLink{
+		ChainSeq:      42,
+		ID:            uuid.MustParse("c3d4e5f6-a7b8-9012-cdef-123456789012"),
+		ItemID:        uuid.MustParse("b9ab5b36-444a-47c4-b7b1-7067a4977e67"),
+		Action:        "adjust",
+		ChangedAt:     time.UnixMicro(1765311400000001),
+		OldData:       strPtr(`{"quantity": 15}`),
+		NewData:       strPtr(`{"quantity": 12}`),
+		QuantityDelta: intPtr(-3),
+		ReasonCode:    strPtr("shipment"),
+		Reference:     strPtr("ORDER-1042"),
+		WarehouseID:   uuidPtr("0b8f8a52-6c1e-4d59-9a57-3f1a2c4e5d6f"),
+		RequestID:     strPtr("req-42"),
+		APIKeyID:      uuidPtr("0b6f4c1e-2d7a-4e8b-9f3c-5a1d2e3f4b5c"),
+		PrevHash:      strPtr(chainRowUpdateHash),
+		Hash:          chainRowAPIKeyHash,
+	}
+}
+
+func TestChainLinkHashMatchesSQL(t *testing.T) {
+	tests := []struct {
+		name string
+		link *models.HistoryChainLink
+		want string
+	}{
+		{name: "api_key_id null", link: chainRowUpdate(), want: chainRowUpdateHash},
+		{name: "api_key_id set", link: chainRowAPIKey(), want: chainRowAPIKeyHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chainLinkHash(tt.link); got != tt.want {
+				t.Fatalf("chainLinkHash = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckChainLink(t *testing.T) {
+	prevHash := strPtr(chainRowUpdateHash)
+
+	tests := []struct {
+		name     string
+		link     func() *models.HistoryChainLink
+		prevHash *string
+		want     string
+	}{
+		{name: "chain head", link: chainRowUpdate, prevHash: nil, want: ""},
+		{name: "linked entry", link: chainRowAPIKey, prevHash: prevHash, want: ""},
+		{
+			name: "api key removed",
+			link: func() *models.HistoryChainLink {
+				link := chainRowAPIKey()
+				link.APIKeyID = nil
+				return link
+			},
+			prevHash: prevHash,
+			want:     models.HistoryChainHashMismatch,
+		},
+		{
+			name: "data edited",
+			link: func() *models.HistoryChainLink {
+				link := chainRowUpdate()
+				link.NewData = strPtr(`{"price": 16000000, "quantity": 16}`)
+				return link
+			},
+			prevHash: nil,
+			want:     models.HistoryChainHashMismatch,
+		},
+		{
+			name:     "entry removed before",
+			link:     chainRowAPIKey,
+			prevHash: strPtr("0000000000000000000000000000000000000000000000000000000000000000"),
+			want:     models.HistoryChainPrevHashMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkChainLink(tt.link(), tt.prevHash); got != tt.want {
+				t.Fatalf("checkChainLink = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeHistoryChainRepo struct {
+	repository.ItemManager
+
+	links      []*models.HistoryChainLink
+	checkpoint *models.HistoryChainAnchor
+	saved      []models.HistoryChainAnchor
+}
+
+func (f *fakeHistoryChainRepo) GetHistoryChain(
+	_ context.Context,
+	afterSeq int64,
+	limit int,
+) ([]*models.HistoryChainLink, error) {
+	var links []*models.HistoryChainLink
+	for _, link := range f.links {
+		if link.ChainSeq > afterSeq && len(links) < limit {
+			links = append(links, link)
+		}
+	}
+
+	return links, nil
+}
+
+func (f *fakeHistoryChainRepo) GetLatestHistoryCheckpoint(context.Context) (*models.HistoryChainAnchor, error) {
+	return f.checkpoint, nil
+}
+
+func (f *fakeHistoryChainRepo) SaveHistoryCheckpoint(_ context.Context, checkpoint models.HistoryChainAnchor) error {
+	f.saved = append(f.saved, checkpoint)
+	return nil
+}
+
+func TestHistoryVerifierAnchors(t *testing.T) {
+	head := &models.HistoryChainAnchor{ChainSeq: 42, Hash: chainRowAPIKeyHash}
+	first := &models.HistoryChainAnchor{ChainSeq: 41, Hash: chainRowUpdateHash}
+
+	tests := []struct {
+		name       string
+		links      []*models.HistoryChainLink
+		expected   *models.HistoryChainAnchor
+		checkpoint *models.HistoryChainAnchor
+		wantReason string
+		wantSeq    int64
+	}{
+		{
+			name:  "no anchors",
+			links: []*models.HistoryChainLink{chainRowUpdate(), chainRowAPIKey()},
+		},
+		{
+			name:     "expected head present",
+			links:    []*models.HistoryChainLink{chainRowUpdate(), chainRowAPIKey()},
+			expected: head,
+		},
+		{
+			name:       "checkpoint inside chain",
+			links:      []*models.HistoryChainLink{chainRowUpdate(), chainRowAPIKey()},
+			checkpoint: first,
+		},
+		{
+			name:       "newest entry deleted",
+			links:      []*models.HistoryChainLink{chainRowUpdate()},
+			expected:   head,
+			wantReason: models.HistoryChainAnchorMissing,
+			wantSeq:    42,
+		},
+		{
+			name:       "newest entry deleted, checkpoint only",
+			links:      []*models.HistoryChainLink{chainRowUpdate()},
+			checkpoint: head,
+			wantReason: models.HistoryChainAnchorMissing,
+			wantSeq:    42,
+		},
+		{
+			name:       "all entries deleted",
+			expected:   head,
+			wantReason: models.HistoryChainAnchorMissing,
+			wantSeq:    42,
+		},
+		{
+			name:       "head replaced",
+			links:      []*models.HistoryChainLink{chainRowUpdate(), chainRowAPIKey()},
+			expected:   &models.HistoryChainAnchor{ChainSeq: 42, Hash: chainRowUpdateHash},
+			wantReason: models.HistoryChainAnchorMismatch,
+			wantSeq:    42,
+		},
+		{
+			name: "anchor skipped by a later entry",
+			links: []*models.HistoryChainLink{chainRowUpdate(), func() *models.HistoryChainLink {
+				link := chainRowAPIKey()
+				link.ChainSeq = 43
+				link.Hash = chainLinkHash(link)
+				return link
+			}()},
+			expected:   head,
+			wantReason: models.HistoryChainAnchorMissing,
+			wantSeq:    43,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeHistoryChainRepo{links: tt.links, checkpoint: tt.checkpoint}
+
+			result, err := NewHistoryVerifier(repo).Verify(context.Background(), tt.expected)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+
+			if tt.wantReason == "" {
+				if result.Broken != nil {
+					t.Fatalf("chain broken at %d: %s", result.Broken.ChainSeq, result.Broken.Reason)
+				}
+				want := models.HistoryChainAnchor{ChainSeq: 42, Hash: chainRowAPIKeyHash}
+				if len(repo.saved) != 1 || repo.saved[0] != want {
+					t.Fatalf("saved checkpoints = %+v, want %+v", repo.saved, want)
+				}
+				return
+			}
+
+			if result.Broken == nil {
+				t.Fatalf("chain reported valid, want %s", tt.wantReason)
+			}
+			if result.Broken.Reason != tt.wantReason || result.Broken.ChainSeq != tt.wantSeq {
+				t.Fatalf("broken = %s at %d, want %s at %d",
+					result.Broken.Reason, result.Broken.ChainSeq, tt.wantReason, tt.wantSeq)
+			}
+			if len(repo.saved) != 0 {
+				t.Fatalf("checkpoint saved for a broken chain: %+v", repo.saved)
+			}
+		})
+	}
+}
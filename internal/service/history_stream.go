@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gookit/slog"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository"
+)
+
+const (
+	historySubscriberBuffer = 64
+	historyReconnectDelay   = 3 * time.Second
+)
+
+type HistorySubscription struct {
+	req    dto.GetHistoryRequest
+	events chan *models.History
+	broker *HistoryBroker
+	once   sync.Once
+}
+
+func (s *HistorySubscription) Events() <-chan *models.History {
+	return s.events
+}
+
+func (s *HistorySubscription) Close() {
+	s.broker.unsubscribe(s)
+}
+
+type HistoryBroker struct {
+	repo        repository.ItemManager
+	log         *slog.Logger
+	mu          sync.Mutex
+	subscribers map[*HistorySubscription]struct{}
+}
+
+func NewHistoryBroker(repo repository.ItemManager, log *slog.Logger) *HistoryBroker {
+	return &HistoryBroker{
+		repo:        repo,
+		log:         log,
+		subscribers: make(map[*HistorySubscription]struct{}),
+	}
+}
+
+func (b *HistoryBroker) Run(ctx context.Context) {
+	for {
+		err := b.repo.ListenHistory(ctx, func(historyID uuid.UUID) {
+			b.publish(ctx, historyID)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		b.log.Errorf("History listener stopped, reconnecting: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(historyReconnectDelay):
+		}
+	}
+}
+
+func (b *HistoryBroker) Subscribe(req dto.GetHistoryRequest) *HistorySubscription {
+	sub := &HistorySubscription{
+		req:    req,
+		events: make(chan *models.History, historySubscriberBuffer),
+		broker: b,
+	}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub
+}
+
+func (b *HistoryBroker) unsubscribe(sub *HistorySubscription) {
+	b.mu.Lock()
+	delete(b.subscribers, sub)
+	b.mu.Unlock()
+
+	sub.once.Do(func() {
+		close(sub.events)
+	})
+}
+
+func (b *HistoryBroker) publish(ctx context.Context, historyID uuid.UUID) {
+	b.mu.Lock()
+	empty := len(b.subscribers) == 0
+	b.mu.Unlock()
+	if empty {
+		return
+	}
+
+	history, err := b.repo.GetHistoryByID(ctx, historyID)
+	if err != nil {
+		b.log.Errorf("History stream: %v", err)
+		return
+	}
+
+	b.mu.Lock()
+	var overflowed []*HistorySubscription
+	for sub := range b.subscribers {
+		if !matchesHistoryFilter(sub.req, history) {
+			continue
+		}
+
+		select {
+		case sub.events <- history:
+		default:
+			overflowed = append(overflowed, sub)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, sub := range overflowed {
+		b.log.Warnf("History stream subscriber is too slow, disconnecting")
+		b.unsubscribe(sub)
+	}
+}
+
+func matchesHistoryFilter(req dto.GetHistoryRequest, history *models.History) bool {
+	switch {
+	case req.ItemID != nil && !uuidPtrEquals(&history.ItemID, *req.ItemID):
+		return false
+	case req.UserID != nil && !uuidPtrEquals(history.UserID, *req.UserID):
+		return false
+	case req.WarehouseID != nil && !uuidPtrEquals(history.WarehouseID, *req.WarehouseID):
+		return false
+	case req.LocationID != nil && !uuidPtrEquals(history.LocationID, *req.LocationID):
+		return false
+	case req.Action != nil && *req.Action != history.Action:
+		return false
+	case req.ReasonCode != nil && (history.ReasonCode == nil || *req.ReasonCode != *history.ReasonCode):
+		return false
+	case req.Reason != nil && (history.Reason == nil || !containsFold(*history.Reason, *req.Reason)):
+		return false
+	case req.RequestID != nil && (history.RequestID == nil || *req.RequestID != *history.RequestID):
+		return false
+	case req.APIKeyID != nil && !uuidPtrEquals(history.APIKeyID, *req.APIKeyID):
+		return false
+	case req.From != nil && history.ChangedAt.Before(*req.From):
+		return false
+	case req.To != nil && history.ChangedAt.After(*req.To):
+		return false
+	}
+
+	return true
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func uuidPtrEquals(id *uuid.UUID, value string) bool {
+	parsed, err := uuid.Parse(value)
+	return err == nil && id != nil && *id == parsed
+}
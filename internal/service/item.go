@@ -3,39 +3,108 @@ package service
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
 	"github.com/kstsm/wb-warehouse-control/internal/converter"
 	"github.com/kstsm/wb-warehouse-control/internal/dto"
 	"github.com/kstsm/wb-warehouse-control/internal/models"
 	"github.com/kstsm/wb-warehouse-control/pkg/export"
+	"github.com/kstsm/wb-warehouse-control/pkg/validator"
 )
 
 func (s *Service) CreateItem(ctx context.Context, req dto.CreateItemRequest, userID *uuid.UUID) (*models.Item, error) {
+	barcodes := req.Barcodes
+	if barcodes == nil {
+		barcodes = []string{}
+	}
+
+	attributes := req.Attributes
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+
+	tags := req.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
 	item := models.Item{
-		ID:          uuid.New(),
-		Name:        req.Name,
-		Description: req.Description,
-		Quantity:    req.Quantity,
-		Price:       req.Price,
-		CreatedAt:   time.Now().UTC(),
-		UpdatedAt:   time.Now().UTC(),
+		ID:           uuid.New(),
+		SKU:          req.SKU,
+		Barcodes:     barcodes,
+		Name:         req.Name,
+		Description:  req.Description,
+		Attributes:   attributes,
+		Tags:         tags,
+		ReorderPoint: req.ReorderPoint,
+		Quantity:     req.Quantity,
+		Price:        req.Price,
+		Version:      1,
+		CreatedAt:    time.Now().UTC(),
+		UpdatedAt:    time.Now().UTC(),
+	}
+
+	if req.CategoryID != nil {
+		categoryID := uuid.MustParse(*req.CategoryID)
+		item.CategoryID = &categoryID
 	}
 
-	if err := s.repo.CreateItem(ctx, item, userID); err != nil {
+	if err := s.repo.CreateItem(ctx, item, req.WarehouseID, req.ChangeReason, userID); err != nil {
 		return nil, err
 	}
 
 	return &item, nil
 }
 
-func (s *Service) GetItems(ctx context.Context) ([]*models.Item, error) {
-	return s.repo.GetItems(ctx)
+func (s *Service) GetItems(ctx context.Context, req dto.GetItemsRequest) ([]*models.Item, int, error) {
+	return s.repo.GetItems(ctx, req)
+}
+
+func (s *Service) GetTrashItems(ctx context.Context, req dto.GetTrashItemsRequest) ([]*models.Item, int, error) {
+	return s.repo.GetTrashItems(ctx, req)
 }
 
 func (s *Service) GetItemByID(ctx context.Context, id uuid.UUID) (*models.Item, error) {
-	return s.repo.GetItemByID(ctx, id)
+	item, err := s.repo.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withStockDetails(ctx, item)
+}
+
+func (s *Service) GetItemBySKU(ctx context.Context, sku string) (*models.Item, error) {
+	item, err := s.repo.GetItemBySKU(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withStockDetails(ctx, item)
+}
+
+func (s *Service) GetItemByBarcode(ctx context.Context, barcode string) (*models.Item, error) {
+	item, err := s.repo.GetItemByBarcode(ctx, barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withStockDetails(ctx, item)
+}
+
+func (s *Service) withStockDetails(ctx context.Context, item *models.Item) (*models.Item, error) {
+	var err error
+	if item.Stocks, err = s.repo.GetItemStocks(ctx, item.ID); err != nil {
+		return nil, err
+	}
+
+	if item.Locations, err = s.repo.GetItemLocations(ctx, item.ID); err != nil {
+		return nil, err
+	}
+
+	return item, nil
 }
 
 func (s *Service) UpdateItem(
@@ -47,25 +116,98 @@ func (s *Service) UpdateItem(
 	return s.repo.UpdateItem(ctx, id, req, userID)
 }
 
-func (s *Service) DeleteItem(ctx context.Context, id uuid.UUID, userID *uuid.UUID) error {
-	return s.repo.DeleteItem(ctx, id, userID)
+func (s *Service) DeleteItem(ctx context.Context, id uuid.UUID, reason dto.ChangeReason, userID *uuid.UUID) error {
+	return s.repo.DeleteItem(ctx, id, reason, userID)
 }
 
-func (s *Service) GetHistory(ctx context.Context, req dto.GetHistoryRequest) ([]*models.History, int, error) {
+func (s *Service) RestoreItem(
+	ctx context.Context,
+	id uuid.UUID,
+	reason dto.ChangeReason,
+	userID *uuid.UUID,
+) (*models.Item, error) {
+	return s.repo.RestoreItem(ctx, id, reason, userID)
+}
+
+func (s *Service) RevertItem(
+	ctx context.Context,
+	id uuid.UUID,
+	req dto.RevertItemRequest,
+	userID *uuid.UUID,
+) (*models.Item, error) {
+	historyID, err := uuid.Parse(req.HistoryID)
+	if err != nil {
+		return nil, fmt.Errorf("parse history_id: %w", err)
+	}
+
+	entry, err := s.repo.GetHistoryByID(ctx, historyID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.ItemID != id {
+		return nil, apperrors.ErrHistoryNotFound
+	}
+
+	snapshot := revertSnapshot(entry)
+	if snapshot == nil {
+		return nil, apperrors.ErrNotRevertible
+	}
+
+	return s.repo.RevertItem(ctx, id, entry.ID, snapshot, req, userID)
+}
+
+func revertSnapshot(entry *models.History) map[string]any {
+	switch validator.ActionType(entry.Action) {
+	case validator.ActionDelete:
+		return entry.OldData
+	case validator.ActionCreate,
+		validator.ActionUpdate,
+		validator.ActionArchive,
+		validator.ActionRestore,
+		validator.ActionAdjust:
+		return entry.NewData
+	case validator.ActionTransfer, validator.ActionPutaway, validator.ActionRelocate, validator.ActionRelease:
+		return nil
+	}
+
+	return nil
+}
+
+func (s *Service) AdjustStock(
+	ctx context.Context,
+	id uuid.UUID,
+	req dto.AdjustStockRequest,
+	userID *uuid.UUID,
+) (*models.Item, error) {
+	return s.repo.AdjustItemQuantity(ctx, id, req, userID)
+}
+
+func (s *Service) GetHistory(ctx context.Context, req dto.GetHistoryRequest) (*models.HistoryPage, error) {
+	return s.repo.GetHistory(ctx, req)
+}
+
+func (s *Service) GetHistoryByItemID(
+	ctx context.Context,
+	itemID uuid.UUID,
+	req dto.GetHistoryRequest,
+) (*models.HistoryPage, error) {
+	id := itemID.String()
+	req.ItemID = &id
+
 	return s.repo.GetHistory(ctx, req)
 }
 
-func (s *Service) GetHistoryByItemID(ctx context.Context, itemID uuid.UUID) ([]*models.History, error) {
-	return s.repo.GetHistoryByItemID(ctx, itemID)
+func (s *Service) SubscribeHistory(req dto.GetHistoryRequest) *HistorySubscription {
+	return s.history.Subscribe(req)
 }
 
 func (s *Service) ExportHistoryCSV(ctx context.Context, req dto.GetHistoryRequest) ([]byte, error) {
-	histories, _, err := s.GetHistory(ctx, req)
+	page, err := s.GetHistory(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	exportHistories := converter.HistoriesToExportResponse(histories)
+	exportHistories := converter.HistoriesToExportResponse(page.Histories)
 
 	var buf bytes.Buffer
 	if writeErr := export.WriteItemsCSV(&buf, nil, exportHistories); writeErr != nil {
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func (s *Service) GetItemAsOf(ctx context.Context, id uuid.UUID, at time.Time) (*models.Item, error) {
+	return s.repo.GetItemAsOf(ctx, id, at)
+}
+
+func (s *Service) GetItemsAsOf(ctx context.Context, at time.Time) ([]*models.Item, error) {
+	return s.repo.GetItemsAsOf(ctx, at)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func (s *Service) CreateLocation(
+	ctx context.Context,
+	req dto.CreateLocationRequest,
+) (*models.StorageLocation, error) {
+	location := models.StorageLocation{
+		ID:          uuid.New(),
+		WarehouseID: uuid.MustParse(req.WarehouseID),
+		Zone:        req.Zone,
+		Aisle:       req.Aisle,
+		Rack:        req.Rack,
+		Bin:         req.Bin,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	return s.repo.CreateLocation(ctx, location)
+}
+
+func (s *Service) GetLocations(ctx context.Context, warehouseID *uuid.UUID) ([]*models.StorageLocation, error) {
+	return s.repo.GetLocations(ctx, warehouseID)
+}
+
+func (s *Service) GetLocationContents(
+	ctx context.Context,
+	id uuid.UUID,
+) (*models.StorageLocation, []*models.LocationContent, error) {
+	location, err := s.repo.GetLocationByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contents, err := s.repo.GetLocationContents(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return location, contents, nil
+}
+
+func (s *Service) PutawayItem(
+	ctx context.Context,
+	req dto.PutawayRequest,
+	userID *uuid.UUID,
+) ([]models.ItemLocation, error) {
+	return s.repo.PutawayItem(ctx, req, userID)
+}
+
+func (s *Service) MoveItemLocation(
+	ctx context.Context,
+	req dto.MoveLocationRequest,
+	userID *uuid.UUID,
+) ([]models.ItemLocation, error) {
+	return s.repo.MoveItemLocation(ctx, req, userID)
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func (s *Service) GetMovements(ctx context.Context, req dto.GetMovementsRequest) (*models.StockMovementPage, error) {
+	return s.repo.GetMovements(ctx, req)
+}
+
+func (s *Service) GetItemMovements(
+	ctx context.Context,
+	itemID uuid.UUID,
+	req dto.GetMovementsRequest,
+) (*models.StockMovementPage, error) {
+	id := itemID.String()
+	req.ItemID = &id
+
+	return s.repo.GetMovements(ctx, req)
+}
+
+func (s *Service) GetStockDiscrepancies(ctx context.Context) ([]*models.StockDiscrepancy, error) {
+	return s.repo.GetStockDiscrepancies(ctx)
+}
@@ -2,9 +2,11 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gookit/slog"
+	"github.com/kstsm/wb-warehouse-control/config"
 	"github.com/kstsm/wb-warehouse-control/internal/dto"
 	"github.com/kstsm/wb-warehouse-control/internal/models"
 	"github.com/kstsm/wb-warehouse-control/internal/repository"
@@ -12,27 +14,107 @@ import (
 )
 
 type ItemManager interface {
-	SignInOrSignUp(ctx context.Context, userName, role string) (string, string, error)
+	SignIn(ctx context.Context, req dto.LoginRequest) (*models.AuthTokens, error)
+	RefreshTokens(ctx context.Context, req dto.RefreshTokenRequest) (*models.AuthTokens, error)
+	Logout(ctx context.Context, claims *jwt.Claims, req dto.LogoutRequest) error
+	IsTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error)
+	Register(ctx context.Context, req dto.RegisterRequest) (*models.User, error)
+	CreateUser(ctx context.Context, req dto.CreateUserRequest, actorID *uuid.UUID) (*models.User, error)
+	GetUsers(ctx context.Context) ([]*models.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	GetActiveUser(ctx context.Context, id uuid.UUID) (*models.User, error)
+	UpdateUser(ctx context.Context, id uuid.UUID, req dto.UpdateUserRequest, actorID *uuid.UUID) (*models.User, error)
+	DeleteUser(ctx context.Context, id uuid.UUID, reason dto.ChangeReason, actorID *uuid.UUID) error
+	GetUserHistory(ctx context.Context, id uuid.UUID) ([]*models.UserHistory, error)
+	CreateAPIKey(ctx context.Context, req dto.CreateAPIKeyRequest, actorID *uuid.UUID) (*models.APIKey, string, error)
+	GetAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+	GetAPIKeyByID(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+	AuthenticateAPIKey(ctx context.Context, raw string) (*models.APIKey, error)
 	CreateItem(ctx context.Context, req dto.CreateItemRequest, userID *uuid.UUID) (*models.Item, error)
-	GetItems(ctx context.Context) ([]*models.Item, error)
+	GetItems(ctx context.Context, req dto.GetItemsRequest) ([]*models.Item, int, error)
+	GetTrashItems(ctx context.Context, req dto.GetTrashItemsRequest) ([]*models.Item, int, error)
 	GetItemByID(ctx context.Context, id uuid.UUID) (*models.Item, error)
+	GetItemBySKU(ctx context.Context, sku string) (*models.Item, error)
+	GetItemByBarcode(ctx context.Context, barcode string) (*models.Item, error)
 	UpdateItem(ctx context.Context, id uuid.UUID, req dto.UpdateItemRequest, userID *uuid.UUID) (*models.Item, error)
-	DeleteItem(ctx context.Context, id uuid.UUID, userID *uuid.UUID) error
-	GetHistory(ctx context.Context, req dto.GetHistoryRequest) ([]*models.History, int, error)
-	GetHistoryByItemID(ctx context.Context, itemID uuid.UUID) ([]*models.History, error)
+	DeleteItem(ctx context.Context, id uuid.UUID, reason dto.ChangeReason, userID *uuid.UUID) error
+	RestoreItem(ctx context.Context, id uuid.UUID, reason dto.ChangeReason, userID *uuid.UUID) (*models.Item, error)
+	AdjustStock(ctx context.Context, id uuid.UUID, req dto.AdjustStockRequest, userID *uuid.UUID) (*models.Item, error)
+	GetHistory(ctx context.Context, req dto.GetHistoryRequest) (*models.HistoryPage, error)
+	GetHistoryByItemID(ctx context.Context, itemID uuid.UUID, req dto.GetHistoryRequest) (*models.HistoryPage, error)
 	ExportHistoryCSV(ctx context.Context, req dto.GetHistoryRequest) ([]byte, error)
+	VerifyHistory(ctx context.Context, req dto.VerifyHistoryRequest) (*models.HistoryVerification, error)
+	GetMovements(ctx context.Context, req dto.GetMovementsRequest) (*models.StockMovementPage, error)
+	GetItemMovements(
+		ctx context.Context,
+		itemID uuid.UUID,
+		req dto.GetMovementsRequest,
+	) (*models.StockMovementPage, error)
+	GetStockDiscrepancies(ctx context.Context) ([]*models.StockDiscrepancy, error)
+	CreateWarehouse(ctx context.Context, req dto.CreateWarehouseRequest) (*models.Warehouse, error)
+	GetWarehouses(ctx context.Context) ([]*models.Warehouse, error)
+	GetWarehouseByID(ctx context.Context, id uuid.UUID) (*models.Warehouse, error)
+	TransferStock(ctx context.Context, req dto.TransferRequest, userID *uuid.UUID) (*models.Transfer, error)
+	CreateLocation(ctx context.Context, req dto.CreateLocationRequest) (*models.StorageLocation, error)
+	GetLocations(ctx context.Context, warehouseID *uuid.UUID) ([]*models.StorageLocation, error)
+	GetLocationContents(
+		ctx context.Context,
+		id uuid.UUID,
+	) (*models.StorageLocation, []*models.LocationContent, error)
+	PutawayItem(ctx context.Context, req dto.PutawayRequest, userID *uuid.UUID) ([]models.ItemLocation, error)
+	MoveItemLocation(
+		ctx context.Context,
+		req dto.MoveLocationRequest,
+		userID *uuid.UUID,
+	) ([]models.ItemLocation, error)
+	CreateCategory(ctx context.Context, req dto.CreateCategoryRequest) (*models.Category, error)
+	GetCategories(ctx context.Context) ([]*models.Category, error)
+	GetCategoryByID(ctx context.Context, id uuid.UUID) (*models.Category, error)
+	UpdateCategory(ctx context.Context, id uuid.UUID, req dto.UpdateCategoryRequest) (*models.Category, error)
+	DeleteCategory(ctx context.Context, id uuid.UUID) error
+	SubscribeHistory(req dto.GetHistoryRequest) *HistorySubscription
+	GetLowStockItems(ctx context.Context, req dto.GetLowStockItemsRequest) ([]*models.Item, int, error)
+	GetItemAsOf(ctx context.Context, id uuid.UUID, at time.Time) (*models.Item, error)
+	RevertItem(ctx context.Context, id uuid.UUID, req dto.RevertItemRequest, userID *uuid.UUID) (*models.Item, error)
+	GetItemsAsOf(ctx context.Context, at time.Time) ([]*models.Item, error)
+	GetAlerts(ctx context.Context, req dto.GetAlertsRequest) ([]*models.Alert, int, error)
+	AcknowledgeAlert(ctx context.Context, id uuid.UUID, userID *uuid.UUID) (*models.Alert, error)
+	CreateWebhook(ctx context.Context, req dto.CreateWebhookRequest) (*models.WebhookSubscription, error)
+	GetWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error)
+	GetWebhookByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, id uuid.UUID, req dto.UpdateWebhookRequest) (*models.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	GetWebhookDeliveries(
+		ctx context.Context,
+		subscriptionID uuid.UUID,
+		req dto.GetWebhookDeliveriesRequest,
+	) (*models.WebhookDeliveryPage, error)
 }
 
 type Service struct {
 	repo           repository.ItemManager
 	log            *slog.Logger
 	tokenGenerator jwt.TokenGenerator
+	history        *HistoryBroker
+	accounts       *Accounts
+	auth           config.Auth
 }
 
-func NewService(repo repository.ItemManager, log *slog.Logger, tokenGenerator jwt.TokenGenerator) ItemManager {
+func NewService(
+	repo repository.ItemManager,
+	log *slog.Logger,
+	tokenGenerator jwt.TokenGenerator,
+	history *HistoryBroker,
+	accounts *Accounts,
+	auth config.Auth,
+) ItemManager {
 	return &Service{
 		repo:           repo,
 		log:            log,
 		tokenGenerator: tokenGenerator,
+		history:        history,
+		accounts:       accounts,
+		auth:           auth,
 	}
 }
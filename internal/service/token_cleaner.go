@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/gookit/slog"
+	"github.com/kstsm/wb-warehouse-control/internal/repository"
+)
+
+const tokenCleanupInterval = time.Hour
+
+type TokenCleaner struct {
+	repo repository.ItemManager
+	log  *slog.Logger
+}
+
+func NewTokenCleaner(repo repository.ItemManager, log *slog.Logger) *TokenCleaner {
+	return &TokenCleaner{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func (c *TokenCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(tokenCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := c.repo.DeleteExpiredTokens(ctx)
+			if err != nil {
+				c.log.Errorf("Token cleanup failed: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				c.log.Infof("Deleted %d expired tokens", deleted)
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/apperrors"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func (s *Service) CreateUser(ctx context.Context, req dto.CreateUserRequest, actorID *uuid.UUID) (*models.User, error) {
+	return s.accounts.Create(ctx, req.UserName, req.Password, req.Role, actorID)
+}
+
+func (s *Service) GetUsers(ctx context.Context) ([]*models.User, error) {
+	return s.repo.GetUsers(ctx)
+}
+
+func (s *Service) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	return s.repo.GetUserByID(ctx, id)
+}
+
+func (s *Service) GetActiveUser(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	user, err := s.repo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DisabledAt != nil {
+		return nil, apperrors.ErrUserDisabled
+	}
+
+	return user, nil
+}
+
+func (s *Service) UpdateUser(
+	ctx context.Context,
+	id uuid.UUID,
+	req dto.UpdateUserRequest,
+	actorID *uuid.UUID,
+) (*models.User, error) {
+	if actorID != nil && *actorID == id && (req.Role != nil || (req.Disabled != nil && *req.Disabled)) {
+		return nil, apperrors.ErrSelfLockout
+	}
+
+	update := models.UserUpdate{
+		Role:     req.Role,
+		Disabled: req.Disabled,
+	}
+
+	if req.Password != nil {
+		hash, err := s.accounts.HashPassword(*req.Password)
+		if err != nil {
+			return nil, err
+		}
+		update.PasswordHash = &hash
+	}
+
+	return s.repo.UpdateUser(ctx, id, update, req.ChangeReason, actorID)
+}
+
+func (s *Service) DeleteUser(ctx context.Context, id uuid.UUID, reason dto.ChangeReason, actorID *uuid.UUID) error {
+	if actorID != nil && *actorID == id {
+		return apperrors.ErrSelfLockout
+	}
+
+	return s.repo.DeleteUser(ctx, id, reason, actorID)
+}
+
+func (s *Service) GetUserHistory(ctx context.Context, id uuid.UUID) ([]*models.UserHistory, error) {
+	if _, err := s.repo.GetUserByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetUserHistory(ctx, id)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+)
+
+func (s *Service) CreateWarehouse(ctx context.Context, req dto.CreateWarehouseRequest) (*models.Warehouse, error) {
+	warehouse := models.Warehouse{
+		ID:        uuid.New(),
+		Code:      req.Code,
+		Name:      req.Name,
+		Address:   req.Address,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	return s.repo.CreateWarehouse(ctx, warehouse)
+}
+
+func (s *Service) GetWarehouses(ctx context.Context) ([]*models.Warehouse, error) {
+	return s.repo.GetWarehouses(ctx)
+}
+
+func (s *Service) GetWarehouseByID(ctx context.Context, id uuid.UUID) (*models.Warehouse, error) {
+	return s.repo.GetWarehouseByID(ctx, id)
+}
+
+func (s *Service) TransferStock(
+	ctx context.Context,
+	req dto.TransferRequest,
+	userID *uuid.UUID,
+) (*models.Transfer, error) {
+	return s.repo.TransferStock(ctx, req, userID)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kstsm/wb-warehouse-control/internal/dto"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/pkg/webhook"
+)
+
+func (s *Service) CreateWebhook(
+	ctx context.Context,
+	req dto.CreateWebhookRequest,
+) (*models.WebhookSubscription, error) {
+	secret := ""
+	if req.Secret != nil {
+		secret = *req.Secret
+	} else {
+		generated, err := webhook.GenerateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	subscription := models.WebhookSubscription{
+		ID:        uuid.New(),
+		URL:       req.URL,
+		Events:    req.Events,
+		Secret:    secret,
+		IsActive:  isActive,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	return s.repo.CreateWebhook(ctx, subscription)
+}
+
+func (s *Service) GetWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	return s.repo.GetWebhooks(ctx)
+}
+
+func (s *Service) GetWebhookByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	return s.repo.GetWebhookByID(ctx, id)
+}
+
+func (s *Service) UpdateWebhook(
+	ctx context.Context,
+	id uuid.UUID,
+	req dto.UpdateWebhookRequest,
+) (*models.WebhookSubscription, error) {
+	return s.repo.UpdateWebhook(ctx, id, req)
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteWebhook(ctx, id)
+}
+
+func (s *Service) GetWebhookDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	req dto.GetWebhookDeliveriesRequest,
+) (*models.WebhookDeliveryPage, error) {
+	if _, err := s.repo.GetWebhookByID(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetWebhookDeliveries(ctx, subscriptionID, req)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/gookit/slog"
+	"github.com/kstsm/wb-warehouse-control/internal/repository"
+)
+
+const webhookCleanupInterval = time.Hour
+
+type WebhookCleaner struct {
+	repo      repository.ItemManager
+	log       *slog.Logger
+	retention time.Duration
+}
+
+func NewWebhookCleaner(repo repository.ItemManager, log *slog.Logger, retention time.Duration) *WebhookCleaner {
+	return &WebhookCleaner{
+		repo:      repo,
+		log:       log,
+		retention: retention,
+	}
+}
+
+func (c *WebhookCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := c.repo.DeleteProcessedWebhookEvents(ctx, time.Now().Add(-c.retention))
+			if err != nil {
+				c.log.Errorf("Webhook outbox cleanup failed: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				c.log.Infof("Deleted %d processed webhook events", deleted)
+			}
+		}
+	}
+}
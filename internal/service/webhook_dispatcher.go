@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gookit/slog"
+	"github.com/kstsm/wb-warehouse-control/config"
+	"github.com/kstsm/wb-warehouse-control/internal/converter"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository"
+	"github.com/kstsm/wb-warehouse-control/pkg/webhook"
+)
+
+type WebhookDispatcher struct {
+	repo   repository.ItemManager
+	log    *slog.Logger
+	sender *webhook.Sender
+	cfg    config.Webhook
+}
+
+func NewWebhookDispatcher(repo repository.ItemManager, log *slog.Logger, cfg config.Webhook) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		repo:   repo,
+		log:    log,
+		sender: webhook.NewSender(cfg.Timeout),
+		cfg:    cfg,
+	}
+}
+
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.DispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.dispatch(ctx)
+		}
+	}
+}
+
+func (d *WebhookDispatcher) dispatch(ctx context.Context) {
+	if _, err := d.repo.FanOutWebhookEvents(ctx, d.cfg.BatchSize); err != nil {
+		d.log.Errorf("Webhook fan-out failed: %v", err)
+		return
+	}
+
+	sendWindow := time.Duration(d.cfg.BatchSize) * d.cfg.Timeout
+	deliveries, err := d.repo.ClaimWebhookDeliveries(ctx, d.cfg.BatchSize, sendWindow+d.cfg.Timeout)
+	if err != nil {
+		d.log.Errorf("Webhook claim failed: %v", err)
+		return
+	}
+
+	batchCtx, cancel := context.WithTimeout(ctx, sendWindow)
+	defer cancel()
+
+	for _, delivery := range deliveries {
+		if batchCtx.Err() != nil {
+			return
+		}
+		d.deliver(batchCtx, delivery)
+	}
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *models.PendingWebhookDelivery) {
+	body, err := json.Marshal(converter.PendingWebhookToPayload(delivery))
+	if err != nil {
+		d.log.Errorf("Webhook payload for delivery %s: %v", delivery.ID, err)
+		return
+	}
+
+	responseStatus, sendErr := d.sender.Send(ctx, delivery.URL, delivery.Secret, webhook.Message{
+		EventID:    delivery.EventID.String(),
+		EventType:  delivery.EventType,
+		DeliveryID: delivery.ID.String(),
+		Body:       body,
+	})
+	if sendErr == nil {
+		if err = d.repo.MarkWebhookDelivered(ctx, delivery.ID, responseStatus); err != nil {
+			d.log.Errorf("Webhook delivery %s: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	attempt := delivery.Attempts + 1
+	status := models.WebhookDeliveryPending
+	if attempt >= d.cfg.MaxAttempts {
+		status = models.WebhookDeliveryFailed
+	}
+	nextAttemptAt := time.Now().UTC().Add(webhook.Backoff(attempt, d.cfg.BackoffBase, d.cfg.BackoffMax))
+
+	var statusPtr *int
+	if responseStatus > 0 {
+		statusPtr = &responseStatus
+	}
+
+	d.log.Warnf("Webhook delivery %s to %s failed (attempt %d, %s): %v",
+		delivery.ID, delivery.URL, attempt, status, sendErr)
+
+	if err = d.repo.MarkWebhookAttemptFailed(
+		ctx,
+		delivery.ID,
+		statusPtr,
+		sendErr.Error(),
+		status,
+		nextAttemptAt,
+	); err != nil {
+		d.log.Errorf("Webhook delivery %s: %v", delivery.ID, err)
+	}
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gookit/slog"
+	"github.com/kstsm/wb-warehouse-control/config"
+	"github.com/kstsm/wb-warehouse-control/internal/models"
+	"github.com/kstsm/wb-warehouse-control/internal/repository"
+	"github.com/kstsm/wb-warehouse-control/pkg/webhook"
+)
+
+type fakeWebhookRepo struct {
+	repository.ItemManager
+
+	mu         sync.Mutex
+	deliveries map[uuid.UUID]*models.PendingWebhookDelivery
+	delivered  map[uuid.UUID]int
+	failures   []failedAttempt
+	lease      time.Duration
+}
+
+type failedAttempt struct {
+	id             uuid.UUID
+	responseStatus *int
+	status         string
+}
+
+func newFakeWebhookRepo(deliveries ...*models.PendingWebhookDelivery) *fakeWebhookRepo {
+	repo := &fakeWebhookRepo{
+		deliveries: make(map[uuid.UUID]*models.PendingWebhookDelivery),
+		delivered:  make(map[uuid.UUID]int),
+	}
+	for _, delivery := range deliveries {
+		repo.deliveries[delivery.ID] = delivery
+	}
+
+	return repo
+}
+
+func (f *fakeWebhookRepo) FanOutWebhookEvents(_ context.Context, _ int) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeWebhookRepo) ClaimWebhookDeliveries(
+	_ context.Context,
+	limit int,
+	lease time.Duration,
+) ([]*models.PendingWebhookDelivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lease = lease
+	var claimed []*models.PendingWebhookDelivery
+	for _, delivery := range f.deliveries {
+		if len(claimed) == limit {
+			break
+		}
+		claimed = append(claimed, delivery)
+	}
+
+	return claimed, nil
+}
+
+func (f *fakeWebhookRepo) MarkWebhookDelivered(_ context.Context, id uuid.UUID, responseStatus int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.delivered[id] = responseStatus
+	delete(f.deliveries, id)
+	return nil
+}
+
+func (f *fakeWebhookRepo) MarkWebhookAttemptFailed(
+	_ context.Context,
+	id uuid.UUID,
+	responseStatus *int,
+	_ string,
+	status string,
+	_ time.Time,
+) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failures = append(f.failures, failedAttempt{id: id, responseStatus: responseStatus, status: status})
+	if delivery, ok := f.deliveries[id]; ok {
+		delivery.Attempts++
+		if status == models.WebhookDeliveryFailed {
+			delete(f.deliveries, id)
+		}
+	}
+	return nil
+}
+
+func testWebhookConfig() config.Webhook {
+	return config.Webhook{
+		DispatchInterval: time.Second,
+		Timeout:          2 * time.Second,
+		MaxAttempts:      3,
+		BackoffBase:      time.Second,
+		BackoffMax:       time.Minute,
+		BatchSize:        10,
+	}
+}
+
+func testPendingDelivery(url, secret string) *models.PendingWebhookDelivery {
+	return &models.PendingWebhookDelivery{
+		ID:         uuid.New(),
+		URL:        url,
+		Secret:     secret,
+		EventID:    uuid.New(),
+		EventType:  "item.updated",
+		ItemID:     uuid.New(),
+		Payload:    []byte(`{"quantity":3}`),
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+func TestWebhookDispatcherSignsAndRetries(t *testing.T) {
+	const secret = "receiver-secret"
+
+	var (
+		mu       sync.Mutex
+		requests int
+		verified []bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+
+		mu.Lock()
+		requests++
+		attempt := requests
+		verified = append(verified, webhook.Verify(secret, timestamp, body, r.Header.Get(webhook.HeaderSignature)))
+		mu.Unlock()
+
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	delivery := testPendingDelivery(server.URL, secret)
+	repo := newFakeWebhookRepo(delivery)
+	dispatcher := NewWebhookDispatcher(repo, slog.New(), testWebhookConfig())
+
+	dispatcher.dispatch(context.Background())
+
+	if len(repo.failures) != 1 {
+		t.Fatalf("failures after 5xx = %d, want 1", len(repo.failures))
+	}
+	failure := repo.failures[0]
+	if failure.status != models.WebhookDeliveryPending {
+		t.Fatalf("status after first 5xx = %q, want %q", failure.status, models.WebhookDeliveryPending)
+	}
+	if failure.responseStatus == nil || *failure.responseStatus != http.StatusServiceUnavailable {
+		t.Fatalf("response status after 5xx = %v, want %d", failure.responseStatus, http.StatusServiceUnavailable)
+	}
+	if _, ok := repo.delivered[delivery.ID]; ok {
+		t.Fatal("delivery marked delivered after 5xx")
+	}
+
+	dispatcher.dispatch(context.Background())
+
+	status, ok := repo.delivered[delivery.ID]
+	if !ok {
+		t.Fatal("delivery not marked delivered after 2xx")
+	}
+	if status != http.StatusNoContent {
+		t.Fatalf("delivered status = %d, want %d", status, http.StatusNoContent)
+	}
+	if len(repo.failures) != 1 {
+		t.Fatalf("failures after 2xx = %d, want 1", len(repo.failures))
+	}
+
+	if requests != 2 {
+		t.Fatalf("receiver got %d requests, want 2", requests)
+	}
+	for i, ok := range verified {
+		if !ok {
+			t.Fatalf("request %d has an invalid %s header", i+1, webhook.HeaderSignature)
+		}
+	}
+}
+
+func TestWebhookDispatcherFailsAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := testWebhookConfig()
+	delivery := testPendingDelivery(server.URL, "secret")
+	delivery.Attempts = cfg.MaxAttempts - 1
+	repo := newFakeWebhookRepo(delivery)
+
+	NewWebhookDispatcher(repo, slog.New(), cfg).dispatch(context.Background())
+
+	if len(repo.failures) != 1 || repo.failures[0].status != models.WebhookDeliveryFailed {
+		t.Fatalf("failures = %+v, want one %q", repo.failures, models.WebhookDeliveryFailed)
+	}
+}
+
+func TestWebhookDispatcherLeaseCoversBatch(t *testing.T) {
+	cfg := testWebhookConfig()
+	repo := newFakeWebhookRepo()
+
+	NewWebhookDispatcher(repo, slog.New(), cfg).dispatch(context.Background())
+
+	if want := time.Duration(cfg.BatchSize) * cfg.Timeout; repo.lease <= want {
+		t.Fatalf("lease = %v, want more than %v", repo.lease, want)
+	}
+}
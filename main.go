@@ -8,7 +8,17 @@ import (
 )
 
 func main() {
-	if err := cmd.Run(); err != nil {
+	run := cmd.Run
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "verify-history":
+			run = func() error { return cmd.VerifyHistory(os.Args[2:]) }
+		case "create-user":
+			run = func() error { return cmd.CreateUser(os.Args[2:]) }
+		}
+	}
+
+	if err := run(); err != nil {
 		slog.Error("application error", "error", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE items_history DROP CONSTRAINT IF EXISTS items_history_item_id_fkey;
+
+-- +goose Down
+DELETE FROM items_history
+WHERE item_id NOT IN (SELECT id FROM items);
+
+ALTER TABLE items_history
+    ADD CONSTRAINT items_history_item_id_fkey
+        FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE;
@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE items ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_items_deleted_at ON items (deleted_at);
+
+ALTER TYPE item_status ADD VALUE IF NOT EXISTS 'archive';
+ALTER TYPE item_status ADD VALUE IF NOT EXISTS 'restore';
+
+-- +goose Down
+-- Значения enum item_status не удаляются: PostgreSQL не поддерживает DROP VALUE
+DROP INDEX IF EXISTS idx_items_deleted_at;
+
+ALTER TABLE items DROP COLUMN IF EXISTS deleted_at;
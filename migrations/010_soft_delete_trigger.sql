@@ -0,0 +1,160 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION item_snapshot(item items)
+RETURNS JSONB AS $func$
+BEGIN
+    RETURN jsonb_build_object(
+        'id', item.id,
+        'name', item.name,
+        'description', item.description,
+        'quantity', item.quantity,
+        'price', item.price,
+        'created_at', item.created_at,
+        'updated_at', item.updated_at,
+        'deleted_at', item.deleted_at
+    );
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_item_changes()
+RETURNS TRIGGER AS $func$
+DECLARE
+    user_uuid UUID;
+    user_id_str TEXT;
+    update_action item_status;
+BEGIN
+    BEGIN
+        user_id_str := current_setting('app.user_id', true);
+        IF user_id_str IS NULL OR trim(user_id_str) = '' THEN
+            user_uuid := NULL;
+        ELSE
+            BEGIN
+                user_uuid := user_id_str::UUID;
+            EXCEPTION WHEN OTHERS THEN
+                user_uuid := NULL;
+            END;
+        END IF;
+    EXCEPTION WHEN OTHERS THEN
+        user_uuid := NULL;
+    END;
+
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), NEW.id, 'create'::item_status, user_uuid, NULL, item_snapshot(NEW));
+
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            update_action := 'archive'::item_status;
+        ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            update_action := 'restore'::item_status;
+        ELSE
+            update_action := 'update'::item_status;
+        END IF;
+
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), NEW.id, update_action, user_uuid, item_snapshot(OLD), item_snapshot(NEW));
+
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), OLD.id, 'delete'::item_status, user_uuid, item_snapshot(OLD), NULL);
+
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_item_changes()
+RETURNS TRIGGER AS $func$
+DECLARE
+    old_json JSONB;
+    new_json JSONB;
+    user_uuid UUID;
+    user_id_str TEXT;
+BEGIN
+    BEGIN
+        user_id_str := current_setting('app.user_id', true);
+        IF user_id_str IS NULL OR trim(user_id_str) = '' THEN
+            user_uuid := NULL;
+        ELSE
+            BEGIN
+                user_uuid := user_id_str::UUID;
+            EXCEPTION WHEN OTHERS THEN
+                user_uuid := NULL;
+            END;
+        END IF;
+    EXCEPTION WHEN OTHERS THEN
+        user_uuid := NULL;
+    END;
+
+    IF TG_OP = 'INSERT' THEN
+        new_json := jsonb_build_object(
+            'id', NEW.id,
+            'name', NEW.name,
+            'description', NEW.description,
+            'quantity', NEW.quantity,
+            'price', NEW.price,
+            'created_at', NEW.created_at,
+            'updated_at', NEW.updated_at
+        );
+
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), NEW.id, 'create'::item_status, user_uuid, NULL, new_json);
+
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        old_json := jsonb_build_object(
+            'id', OLD.id,
+            'name', OLD.name,
+            'description', OLD.description,
+            'quantity', OLD.quantity,
+            'price', OLD.price,
+            'created_at', OLD.created_at,
+            'updated_at', OLD.updated_at
+        );
+
+        new_json := jsonb_build_object(
+            'id', NEW.id,
+            'name', NEW.name,
+            'description', NEW.description,
+            'quantity', NEW.quantity,
+            'price', NEW.price,
+            'created_at', NEW.created_at,
+            'updated_at', NEW.updated_at
+        );
+
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), NEW.id, 'update'::item_status, user_uuid, old_json, new_json);
+
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        old_json := jsonb_build_object(
+            'id', OLD.id,
+            'name', OLD.name,
+            'description', OLD.description,
+            'quantity', OLD.quantity,
+            'price', OLD.price,
+            'created_at', OLD.created_at,
+            'updated_at', OLD.updated_at
+        );
+
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), OLD.id, 'delete'::item_status, user_uuid, old_json, NULL);
+
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS item_snapshot(items);
+-- +goose StatementEnd
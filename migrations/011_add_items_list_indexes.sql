@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_items_name_trgm ON items USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_items_quantity ON items (quantity);
+CREATE INDEX IF NOT EXISTS idx_items_price ON items (price);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_items_price;
+DROP INDEX IF EXISTS idx_items_quantity;
+DROP INDEX IF EXISTS idx_items_name_trgm;
@@ -0,0 +1,7 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_history_changed_at_id ON items_history (changed_at, id);
+CREATE INDEX IF NOT EXISTS idx_history_item_id_changed_at_id ON items_history (item_id, changed_at, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_history_item_id_changed_at_id;
+DROP INDEX IF EXISTS idx_history_changed_at_id;
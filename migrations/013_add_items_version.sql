@@ -0,0 +1,42 @@
+-- +goose Up
+ALTER TABLE items ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION item_snapshot(item items)
+RETURNS JSONB AS $func$
+BEGIN
+    RETURN jsonb_build_object(
+        'id', item.id,
+        'name', item.name,
+        'description', item.description,
+        'quantity', item.quantity,
+        'price', item.price,
+        'version', item.version,
+        'created_at', item.created_at,
+        'updated_at', item.updated_at,
+        'deleted_at', item.deleted_at
+    );
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION item_snapshot(item items)
+RETURNS JSONB AS $func$
+BEGIN
+    RETURN jsonb_build_object(
+        'id', item.id,
+        'name', item.name,
+        'description', item.description,
+        'quantity', item.quantity,
+        'price', item.price,
+        'created_at', item.created_at,
+        'updated_at', item.updated_at,
+        'deleted_at', item.deleted_at
+    );
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+ALTER TABLE items DROP COLUMN IF EXISTS version;
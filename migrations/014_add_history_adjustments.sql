@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TYPE item_status ADD VALUE IF NOT EXISTS 'adjust';
+
+ALTER TABLE items_history
+    ADD COLUMN IF NOT EXISTS quantity_delta INT,
+    ADD COLUMN IF NOT EXISTS reason_code    VARCHAR(32),
+    ADD COLUMN IF NOT EXISTS reference      VARCHAR(128);
+
+CREATE INDEX IF NOT EXISTS idx_history_reason_code ON items_history (reason_code);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_history_reason_code;
+
+ALTER TABLE items_history
+    DROP COLUMN IF EXISTS reference,
+    DROP COLUMN IF EXISTS reason_code,
+    DROP COLUMN IF EXISTS quantity_delta;
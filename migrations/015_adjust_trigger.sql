@@ -0,0 +1,120 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_item_changes()
+RETURNS TRIGGER AS $func$
+DECLARE
+    user_uuid UUID;
+    user_id_str TEXT;
+    update_action item_status;
+    adjust_reason TEXT;
+    adjust_reference TEXT;
+    adjust_delta INT;
+BEGIN
+    BEGIN
+        user_id_str := current_setting('app.user_id', true);
+        IF user_id_str IS NULL OR trim(user_id_str) = '' THEN
+            user_uuid := NULL;
+        ELSE
+            BEGIN
+                user_uuid := user_id_str::UUID;
+            EXCEPTION WHEN OTHERS THEN
+                user_uuid := NULL;
+            END;
+        END IF;
+    EXCEPTION WHEN OTHERS THEN
+        user_uuid := NULL;
+    END;
+
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), NEW.id, 'create'::item_status, user_uuid, NULL, item_snapshot(NEW));
+
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        adjust_reason := NULLIF(current_setting('app.adjust_reason', true), '');
+
+        IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            update_action := 'archive'::item_status;
+        ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            update_action := 'restore'::item_status;
+        ELSIF adjust_reason IS NOT NULL THEN
+            update_action := 'adjust'::item_status;
+            adjust_delta := NEW.quantity - OLD.quantity;
+            adjust_reference := NULLIF(current_setting('app.adjust_reference', true), '');
+        ELSE
+            update_action := 'update'::item_status;
+            adjust_reason := NULL;
+        END IF;
+
+        INSERT INTO items_history (
+            id, item_id, action, user_id, old_data, new_data, quantity_delta, reason_code, reference
+        )
+        VALUES (
+            gen_random_uuid(), NEW.id, update_action, user_uuid, item_snapshot(OLD), item_snapshot(NEW),
+            adjust_delta, adjust_reason, adjust_reference
+        );
+
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), OLD.id, 'delete'::item_status, user_uuid, item_snapshot(OLD), NULL);
+
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_item_changes()
+RETURNS TRIGGER AS $func$
+DECLARE
+    user_uuid UUID;
+    user_id_str TEXT;
+    update_action item_status;
+BEGIN
+    BEGIN
+        user_id_str := current_setting('app.user_id', true);
+        IF user_id_str IS NULL OR trim(user_id_str) = '' THEN
+            user_uuid := NULL;
+        ELSE
+            BEGIN
+                user_uuid := user_id_str::UUID;
+            EXCEPTION WHEN OTHERS THEN
+                user_uuid := NULL;
+            END;
+        END IF;
+    EXCEPTION WHEN OTHERS THEN
+        user_uuid := NULL;
+    END;
+
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), NEW.id, 'create'::item_status, user_uuid, NULL, item_snapshot(NEW));
+
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            update_action := 'archive'::item_status;
+        ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            update_action := 'restore'::item_status;
+        ELSE
+            update_action := 'update'::item_status;
+        END IF;
+
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), NEW.id, update_action, user_uuid, item_snapshot(OLD), item_snapshot(NEW));
+
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), OLD.id, 'delete'::item_status, user_uuid, item_snapshot(OLD), NULL);
+
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
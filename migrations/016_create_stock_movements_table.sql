@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE TYPE movement_kind AS ENUM ('receipt', 'issue', 'transfer', 'adjustment', 'return');
+
+CREATE TABLE IF NOT EXISTS stock_movements
+(
+    id         UUID PRIMARY KEY,
+    item_id    UUID          NOT NULL REFERENCES items (id),
+    kind       movement_kind NOT NULL,
+    quantity   INT           NOT NULL CHECK (quantity <> 0),
+    reference  VARCHAR(128),
+    user_id    UUID          REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_movements_item_id ON stock_movements (item_id);
+CREATE INDEX IF NOT EXISTS idx_movements_user_id ON stock_movements (user_id);
+CREATE INDEX IF NOT EXISTS idx_movements_kind ON stock_movements (kind);
+CREATE INDEX IF NOT EXISTS idx_movements_created_at_id ON stock_movements (created_at, id);
+
+-- Начальные остатки, чтобы сумма движений совпадала с items.quantity
+INSERT INTO stock_movements (id, item_id, kind, quantity, reference, user_id, created_at)
+SELECT gen_random_uuid(), id, 'adjustment'::movement_kind, quantity, 'opening balance', NULL, NOW()
+FROM items
+WHERE quantity <> 0;
+
+-- +goose Down
+DROP TABLE IF EXISTS stock_movements;
+DROP TYPE IF EXISTS movement_kind;
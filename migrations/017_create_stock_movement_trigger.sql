@@ -0,0 +1,63 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_stock_movement()
+RETURNS TRIGGER AS $func$
+DECLARE
+    user_uuid UUID;
+    movement_delta INT;
+    movement_kind_str TEXT;
+    adjust_reason TEXT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        movement_delta := NEW.quantity;
+    ELSE
+        movement_delta := NEW.quantity - OLD.quantity;
+    END IF;
+
+    IF movement_delta = 0 THEN
+        RETURN NEW;
+    END IF;
+
+    BEGIN
+        user_uuid := NULLIF(trim(current_setting('app.user_id', true)), '')::UUID;
+    EXCEPTION WHEN OTHERS THEN
+        user_uuid := NULL;
+    END;
+
+    movement_kind_str := NULLIF(current_setting('app.movement_kind', true), '');
+    IF movement_kind_str IS NULL THEN
+        adjust_reason := NULLIF(current_setting('app.adjust_reason', true), '');
+        movement_kind_str := CASE
+            WHEN TG_OP = 'INSERT' THEN 'receipt'
+            WHEN adjust_reason = 'receipt' THEN 'receipt'
+            WHEN adjust_reason = 'shipment' THEN 'issue'
+            WHEN adjust_reason = 'return' THEN 'return'
+            ELSE 'adjustment'
+        END;
+    END IF;
+
+    INSERT INTO stock_movements (id, item_id, kind, quantity, reference, user_id)
+    VALUES (
+        gen_random_uuid(),
+        NEW.id,
+        movement_kind_str::movement_kind,
+        movement_delta,
+        NULLIF(current_setting('app.adjust_reference', true), ''),
+        user_uuid
+    );
+
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER items_stock_movement_trigger
+    AFTER INSERT OR UPDATE OF quantity ON items
+    FOR EACH ROW
+    EXECUTE FUNCTION log_stock_movement();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS items_stock_movement_trigger ON items;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS log_stock_movement();
+-- +goose StatementEnd
@@ -0,0 +1,59 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS warehouses
+(
+    id         UUID PRIMARY KEY,
+    code       VARCHAR(32) UNIQUE NOT NULL,
+    name       VARCHAR(128)       NOT NULL,
+    address    TEXT,
+    is_default BOOLEAN            NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ        NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ        NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_warehouses_default ON warehouses (is_default) WHERE is_default;
+
+INSERT INTO warehouses (id, code, name, is_default)
+VALUES (gen_random_uuid(), 'MAIN', 'Основной склад', TRUE);
+
+CREATE TABLE IF NOT EXISTS item_stocks
+(
+    item_id      UUID        NOT NULL REFERENCES items (id),
+    warehouse_id UUID        NOT NULL REFERENCES warehouses (id),
+    quantity     INT         NOT NULL CHECK (quantity >= 0),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, warehouse_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_stocks_warehouse_id ON item_stocks (warehouse_id);
+
+-- Весь текущий остаток переносится на основной склад
+INSERT INTO item_stocks (item_id, warehouse_id, quantity)
+SELECT i.id, w.id, i.quantity
+FROM items i
+         CROSS JOIN warehouses w
+WHERE w.is_default
+  AND i.quantity > 0;
+
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS warehouse_id UUID REFERENCES warehouses (id);
+
+UPDATE stock_movements
+SET warehouse_id = (SELECT id FROM warehouses WHERE is_default)
+WHERE warehouse_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_movements_warehouse_id ON stock_movements (warehouse_id);
+
+ALTER TABLE items_history ADD COLUMN IF NOT EXISTS warehouse_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_history_warehouse_id ON items_history (warehouse_id);
+
+ALTER TYPE item_status ADD VALUE IF NOT EXISTS 'transfer';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_history_warehouse_id;
+ALTER TABLE items_history DROP COLUMN IF EXISTS warehouse_id;
+
+DROP INDEX IF EXISTS idx_movements_warehouse_id;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS warehouse_id;
+
+DROP TABLE IF EXISTS item_stocks;
+DROP TABLE IF EXISTS warehouses;
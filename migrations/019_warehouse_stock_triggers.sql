@@ -0,0 +1,381 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION current_warehouse_id()
+RETURNS UUID AS $func$
+DECLARE
+    warehouse_str TEXT;
+    warehouse_uuid UUID;
+BEGIN
+    warehouse_str := NULLIF(trim(current_setting('app.warehouse_id', true)), '');
+    IF warehouse_str IS NOT NULL THEN
+        RETURN warehouse_str::UUID;
+    END IF;
+
+    SELECT id INTO warehouse_uuid FROM warehouses WHERE is_default;
+    RETURN warehouse_uuid;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION sync_item_stock()
+RETURNS TRIGGER AS $func$
+DECLARE
+    stock_delta INT;
+    warehouse_uuid UUID;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        stock_delta := NEW.quantity;
+    ELSE
+        stock_delta := NEW.quantity - OLD.quantity;
+    END IF;
+
+    IF stock_delta = 0 THEN
+        RETURN NEW;
+    END IF;
+
+    warehouse_uuid := current_warehouse_id();
+
+    UPDATE item_stocks
+    SET quantity = quantity + stock_delta,
+        updated_at = NOW()
+    WHERE item_id = NEW.id
+      AND warehouse_id = warehouse_uuid;
+
+    IF NOT FOUND THEN
+        INSERT INTO item_stocks (item_id, warehouse_id, quantity)
+        VALUES (NEW.id, warehouse_uuid, stock_delta);
+    END IF;
+
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER items_stock_sync_trigger
+    AFTER INSERT OR UPDATE OF quantity ON items
+    FOR EACH ROW
+    EXECUTE FUNCTION sync_item_stock();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_stock_movement()
+RETURNS TRIGGER AS $func$
+DECLARE
+    user_uuid UUID;
+    movement_delta INT;
+    movement_kind_str TEXT;
+    adjust_reason TEXT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        movement_delta := NEW.quantity;
+    ELSE
+        movement_delta := NEW.quantity - OLD.quantity;
+    END IF;
+
+    IF movement_delta = 0 THEN
+        RETURN NEW;
+    END IF;
+
+    BEGIN
+        user_uuid := NULLIF(trim(current_setting('app.user_id', true)), '')::UUID;
+    EXCEPTION WHEN OTHERS THEN
+        user_uuid := NULL;
+    END;
+
+    movement_kind_str := NULLIF(current_setting('app.movement_kind', true), '');
+    IF movement_kind_str IS NULL THEN
+        adjust_reason := NULLIF(current_setting('app.adjust_reason', true), '');
+        movement_kind_str := CASE
+            WHEN TG_OP = 'INSERT' THEN 'receipt'
+            WHEN adjust_reason = 'receipt' THEN 'receipt'
+            WHEN adjust_reason = 'shipment' THEN 'issue'
+            WHEN adjust_reason = 'return' THEN 'return'
+            ELSE 'adjustment'
+        END;
+    END IF;
+
+    INSERT INTO stock_movements (id, item_id, warehouse_id, kind, quantity, reference, user_id)
+    VALUES (
+        gen_random_uuid(),
+        NEW.id,
+        current_warehouse_id(),
+        movement_kind_str::movement_kind,
+        movement_delta,
+        NULLIF(current_setting('app.adjust_reference', true), ''),
+        user_uuid
+    );
+
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_item_changes()
+RETURNS TRIGGER AS $func$
+DECLARE
+    user_uuid UUID;
+    user_id_str TEXT;
+    update_action item_status;
+    adjust_reason TEXT;
+    adjust_reference TEXT;
+    adjust_delta INT;
+    warehouse_uuid UUID;
+BEGIN
+    BEGIN
+        user_id_str := current_setting('app.user_id', true);
+        IF user_id_str IS NULL OR trim(user_id_str) = '' THEN
+            user_uuid := NULL;
+        ELSE
+            BEGIN
+                user_uuid := user_id_str::UUID;
+            EXCEPTION WHEN OTHERS THEN
+                user_uuid := NULL;
+            END;
+        END IF;
+    EXCEPTION WHEN OTHERS THEN
+        user_uuid := NULL;
+    END;
+
+    IF TG_OP = 'INSERT' THEN
+        IF NEW.quantity <> 0 THEN
+            warehouse_uuid := current_warehouse_id();
+        END IF;
+
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data, warehouse_id)
+        VALUES (
+            gen_random_uuid(), NEW.id, 'create'::item_status, user_uuid, NULL, item_snapshot(NEW), warehouse_uuid
+        );
+
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        adjust_reason := NULLIF(current_setting('app.adjust_reason', true), '');
+
+        IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            update_action := 'archive'::item_status;
+        ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            update_action := 'restore'::item_status;
+        ELSIF adjust_reason IS NOT NULL THEN
+            update_action := 'adjust'::item_status;
+            adjust_delta := NEW.quantity - OLD.quantity;
+            adjust_reference := NULLIF(current_setting('app.adjust_reference', true), '');
+        ELSE
+            update_action := 'update'::item_status;
+            adjust_reason := NULL;
+        END IF;
+
+        IF NEW.quantity <> OLD.quantity THEN
+            warehouse_uuid := current_warehouse_id();
+        END IF;
+
+        INSERT INTO items_history (
+            id, item_id, action, user_id, old_data, new_data, quantity_delta, reason_code, reference, warehouse_id
+        )
+        VALUES (
+            gen_random_uuid(), NEW.id, update_action, user_uuid, item_snapshot(OLD), item_snapshot(NEW),
+            adjust_delta, adjust_reason, adjust_reference, warehouse_uuid
+        );
+
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), OLD.id, 'delete'::item_status, user_uuid, item_snapshot(OLD), NULL);
+
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_item_stock_transfer()
+RETURNS TRIGGER AS $func$
+DECLARE
+    user_uuid UUID;
+    old_quantity INT;
+    transfer_delta INT;
+    transfer_reference TEXT;
+BEGIN
+    IF NULLIF(current_setting('app.movement_kind', true), '') IS DISTINCT FROM 'transfer' THEN
+        RETURN NEW;
+    END IF;
+
+    IF TG_OP = 'INSERT' THEN
+        old_quantity := 0;
+    ELSE
+        old_quantity := OLD.quantity;
+    END IF;
+
+    transfer_delta := NEW.quantity - old_quantity;
+    IF transfer_delta = 0 THEN
+        RETURN NEW;
+    END IF;
+
+    BEGIN
+        user_uuid := NULLIF(trim(current_setting('app.user_id', true)), '')::UUID;
+    EXCEPTION WHEN OTHERS THEN
+        user_uuid := NULL;
+    END;
+
+    transfer_reference := NULLIF(current_setting('app.adjust_reference', true), '');
+
+    INSERT INTO stock_movements (id, item_id, warehouse_id, kind, quantity, reference, user_id)
+    VALUES (
+        gen_random_uuid(), NEW.item_id, NEW.warehouse_id, 'transfer'::movement_kind,
+        transfer_delta, transfer_reference, user_uuid
+    );
+
+    INSERT INTO items_history (
+        id, item_id, action, user_id, old_data, new_data, quantity_delta, reference, warehouse_id
+    )
+    VALUES (
+        gen_random_uuid(), NEW.item_id, 'transfer'::item_status, user_uuid,
+        jsonb_build_object('warehouse_id', NEW.warehouse_id, 'quantity', old_quantity),
+        jsonb_build_object('warehouse_id', NEW.warehouse_id, 'quantity', NEW.quantity),
+        transfer_delta, transfer_reference, NEW.warehouse_id
+    );
+
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER item_stocks_transfer_trigger
+    AFTER INSERT OR UPDATE OF quantity ON item_stocks
+    FOR EACH ROW
+    EXECUTE FUNCTION log_item_stock_transfer();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS item_stocks_transfer_trigger ON item_stocks;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS log_item_stock_transfer();
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS items_stock_sync_trigger ON items;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS sync_item_stock();
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_stock_movement()
+RETURNS TRIGGER AS $func$
+DECLARE
+    user_uuid UUID;
+    movement_delta INT;
+    movement_kind_str TEXT;
+    adjust_reason TEXT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        movement_delta := NEW.quantity;
+    ELSE
+        movement_delta := NEW.quantity - OLD.quantity;
+    END IF;
+
+    IF movement_delta = 0 THEN
+        RETURN NEW;
+    END IF;
+
+    BEGIN
+        user_uuid := NULLIF(trim(current_setting('app.user_id', true)), '')::UUID;
+    EXCEPTION WHEN OTHERS THEN
+        user_uuid := NULL;
+    END;
+
+    movement_kind_str := NULLIF(current_setting('app.movement_kind', true), '');
+    IF movement_kind_str IS NULL THEN
+        adjust_reason := NULLIF(current_setting('app.adjust_reason', true), '');
+        movement_kind_str := CASE
+            WHEN TG_OP = 'INSERT' THEN 'receipt'
+            WHEN adjust_reason = 'receipt' THEN 'receipt'
+            WHEN adjust_reason = 'shipment' THEN 'issue'
+            WHEN adjust_reason = 'return' THEN 'return'
+            ELSE 'adjustment'
+        END;
+    END IF;
+
+    INSERT INTO stock_movements (id, item_id, kind, quantity, reference, user_id)
+    VALUES (
+        gen_random_uuid(),
+        NEW.id,
+        movement_kind_str::movement_kind,
+        movement_delta,
+        NULLIF(current_setting('app.adjust_reference', true), ''),
+        user_uuid
+    );
+
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_item_changes()
+RETURNS TRIGGER AS $func$
+DECLARE
+    user_uuid UUID;
+    user_id_str TEXT;
+    update_action item_status;
+    adjust_reason TEXT;
+    adjust_reference TEXT;
+    adjust_delta INT;
+BEGIN
+    BEGIN
+        user_id_str := current_setting('app.user_id', true);
+        IF user_id_str IS NULL OR trim(user_id_str) = '' THEN
+            user_uuid := NULL;
+        ELSE
+            BEGIN
+                user_uuid := user_id_str::UUID;
+            EXCEPTION WHEN OTHERS THEN
+                user_uuid := NULL;
+            END;
+        END IF;
+    EXCEPTION WHEN OTHERS THEN
+        user_uuid := NULL;
+    END;
+
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), NEW.id, 'create'::item_status, user_uuid, NULL, item_snapshot(NEW));
+
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        adjust_reason := NULLIF(current_setting('app.adjust_reason', true), '');
+
+        IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            update_action := 'archive'::item_status;
+        ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            update_action := 'restore'::item_status;
+        ELSIF adjust_reason IS NOT NULL THEN
+            update_action := 'adjust'::item_status;
+            adjust_delta := NEW.quantity - OLD.quantity;
+            adjust_reference := NULLIF(current_setting('app.adjust_reference', true), '');
+        ELSE
+            update_action := 'update'::item_status;
+            adjust_reason := NULL;
+        END IF;
+
+        INSERT INTO items_history (
+            id, item_id, action, user_id, old_data, new_data, quantity_delta, reason_code, reference
+        )
+        VALUES (
+            gen_random_uuid(), NEW.id, update_action, user_uuid, item_snapshot(OLD), item_snapshot(NEW),
+            adjust_delta, adjust_reason, adjust_reference
+        );
+
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        INSERT INTO items_history (id, item_id, action, user_id, old_data, new_data)
+        VALUES (gen_random_uuid(), OLD.id, 'delete'::item_status, user_uuid, item_snapshot(OLD), NULL);
+
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS current_warehouse_id();
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS storage_locations
+(
+    id           UUID PRIMARY KEY,
+    warehouse_id UUID        NOT NULL REFERENCES warehouses (id),
+    zone         VARCHAR(16) NOT NULL,
+    aisle        VARCHAR(16) NOT NULL,
+    rack         VARCHAR(16) NOT NULL,
+    bin          VARCHAR(16) NOT NULL,
+    code         VARCHAR(67) GENERATED ALWAYS AS (zone || '-' || aisle || '-' || rack || '-' || bin) STORED,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (warehouse_id, code)
+);
+
+CREATE TABLE IF NOT EXISTS location_stocks
+(
+    item_id     UUID        NOT NULL REFERENCES items (id),
+    location_id UUID        NOT NULL REFERENCES storage_locations (id),
+    quantity    INT         NOT NULL CHECK (quantity >= 0),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, location_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_location_stocks_location_id ON location_stocks (location_id);
+
+ALTER TABLE items_history ADD COLUMN IF NOT EXISTS location_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_history_location_id ON items_history (location_id);
+
+ALTER TYPE item_status ADD VALUE IF NOT EXISTS 'putaway';
+ALTER TYPE item_status ADD VALUE IF NOT EXISTS 'relocate';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_history_location_id;
+ALTER TABLE items_history DROP COLUMN IF EXISTS location_id;
+
+DROP TABLE IF EXISTS location_stocks;
+DROP TABLE IF EXISTS storage_locations;
@@ -0,0 +1,122 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_location_stock_change()
+RETURNS TRIGGER AS $func$
+DECLARE
+    user_uuid UUID;
+    location_action TEXT;
+    old_quantity INT;
+    location_delta INT;
+    location_row storage_locations%ROWTYPE;
+BEGIN
+    location_action := NULLIF(current_setting('app.location_action', true), '');
+    IF location_action IS NULL THEN
+        RETURN NEW;
+    END IF;
+
+    IF TG_OP = 'INSERT' THEN
+        old_quantity := 0;
+    ELSE
+        old_quantity := OLD.quantity;
+    END IF;
+
+    location_delta := NEW.quantity - old_quantity;
+    IF location_delta = 0 THEN
+        RETURN NEW;
+    END IF;
+
+    BEGIN
+        user_uuid := NULLIF(trim(current_setting('app.user_id', true)), '')::UUID;
+    EXCEPTION WHEN OTHERS THEN
+        user_uuid := NULL;
+    END;
+
+    SELECT * INTO location_row FROM storage_locations WHERE id = NEW.location_id;
+
+    INSERT INTO items_history (
+        id, item_id, action, user_id, old_data, new_data, quantity_delta, reference, warehouse_id, location_id
+    )
+    VALUES (
+        gen_random_uuid(), NEW.item_id, location_action::item_status, user_uuid,
+        jsonb_build_object('location_id', NEW.location_id, 'location_code', location_row.code, 'quantity', old_quantity),
+        jsonb_build_object('location_id', NEW.location_id, 'location_code', location_row.code, 'quantity', NEW.quantity),
+        location_delta, NULLIF(current_setting('app.adjust_reference', true), ''),
+        location_row.warehouse_id, NEW.location_id
+    );
+
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER location_stocks_history_trigger
+    AFTER INSERT OR UPDATE OF quantity ON location_stocks
+    FOR EACH ROW
+    EXECUTE FUNCTION log_location_stock_change();
+
+-- Если остаток на складе стал меньше размещённого по ячейкам, излишек снимается с ячеек
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION release_location_stock()
+RETURNS TRIGGER AS $func$
+DECLARE
+    excess INT;
+    take INT;
+    location_rec RECORD;
+BEGIN
+    IF NEW.quantity >= OLD.quantity THEN
+        RETURN NEW;
+    END IF;
+
+    SELECT COALESCE(SUM(ls.quantity), 0) - NEW.quantity
+    INTO excess
+    FROM location_stocks ls
+             JOIN storage_locations l ON l.id = ls.location_id
+    WHERE ls.item_id = NEW.item_id
+      AND l.warehouse_id = NEW.warehouse_id;
+
+    IF excess <= 0 THEN
+        RETURN NEW;
+    END IF;
+
+    FOR location_rec IN
+        SELECT ls.location_id, ls.quantity
+        FROM location_stocks ls
+                 JOIN storage_locations l ON l.id = ls.location_id
+        WHERE ls.item_id = NEW.item_id
+          AND l.warehouse_id = NEW.warehouse_id
+          AND ls.quantity > 0
+        ORDER BY l.code
+        FOR UPDATE OF ls
+    LOOP
+        take := LEAST(location_rec.quantity, excess);
+
+        UPDATE location_stocks
+        SET quantity = quantity - take,
+            updated_at = NOW()
+        WHERE item_id = NEW.item_id
+          AND location_id = location_rec.location_id;
+
+        excess := excess - take;
+        EXIT WHEN excess = 0;
+    END LOOP;
+
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER item_stocks_release_trigger
+    AFTER UPDATE OF quantity ON item_stocks
+    FOR EACH ROW
+    EXECUTE FUNCTION release_location_stock();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS item_stocks_release_trigger ON item_stocks;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS release_location_stock();
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS location_stocks_history_trigger ON location_stocks;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS log_location_stock_change();
+-- +goose StatementEnd
@@ -0,0 +1,95 @@
+-- +goose Up
+ALTER TABLE items ADD COLUMN IF NOT EXISTS sku VARCHAR(64);
+
+-- У существующих товаров артикула нет, поэтому он собирается из ID
+UPDATE items SET sku = 'LEGACY-' || id::TEXT WHERE sku IS NULL;
+
+ALTER TABLE items ALTER COLUMN sku SET NOT NULL;
+ALTER TABLE items ADD CONSTRAINT items_sku_key UNIQUE (sku);
+
+ALTER TABLE items ADD COLUMN IF NOT EXISTS barcodes TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS item_barcodes
+(
+    barcode VARCHAR(64) PRIMARY KEY,
+    item_id UUID NOT NULL REFERENCES items (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_barcodes_item_id ON item_barcodes (item_id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION sync_item_barcodes()
+RETURNS TRIGGER AS $func$
+BEGIN
+    DELETE FROM item_barcodes
+    WHERE item_id = NEW.id
+      AND NOT (barcode = ANY (NEW.barcodes));
+
+    INSERT INTO item_barcodes (barcode, item_id)
+    SELECT DISTINCT b, NEW.id
+    FROM unnest(NEW.barcodes) AS b
+    WHERE NOT EXISTS (
+        SELECT 1 FROM item_barcodes ib WHERE ib.barcode = b AND ib.item_id = NEW.id
+    );
+
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER items_barcodes_sync_trigger
+    AFTER INSERT OR UPDATE OF barcodes ON items
+    FOR EACH ROW
+    EXECUTE FUNCTION sync_item_barcodes();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION item_snapshot(item items)
+RETURNS JSONB AS $func$
+BEGIN
+    RETURN jsonb_build_object(
+        'id', item.id,
+        'sku', item.sku,
+        'barcodes', to_jsonb(item.barcodes),
+        'name', item.name,
+        'description', item.description,
+        'quantity', item.quantity,
+        'price', item.price,
+        'version', item.version,
+        'created_at', item.created_at,
+        'updated_at', item.updated_at,
+        'deleted_at', item.deleted_at
+    );
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION item_snapshot(item items)
+RETURNS JSONB AS $func$
+BEGIN
+    RETURN jsonb_build_object(
+        'id', item.id,
+        'name', item.name,
+        'description', item.description,
+        'quantity', item.quantity,
+        'price', item.price,
+        'version', item.version,
+        'created_at', item.created_at,
+        'updated_at', item.updated_at,
+        'deleted_at', item.deleted_at
+    );
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS items_barcodes_sync_trigger ON items;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS sync_item_barcodes();
+-- +goose StatementEnd
+
+DROP TABLE IF EXISTS item_barcodes;
+
+ALTER TABLE items DROP COLUMN IF EXISTS barcodes;
+ALTER TABLE items DROP CONSTRAINT IF EXISTS items_sku_key;
+ALTER TABLE items DROP COLUMN IF EXISTS sku;
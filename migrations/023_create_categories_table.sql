@@ -0,0 +1,72 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS categories
+(
+    id         UUID PRIMARY KEY,
+    parent_id  UUID,
+    name       VARCHAR(128) NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT categories_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES categories (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_parent_name
+    ON categories (COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::UUID), lower(name));
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id);
+
+ALTER TABLE items ADD COLUMN IF NOT EXISTS category_id UUID;
+ALTER TABLE items
+    ADD CONSTRAINT items_category_id_fkey FOREIGN KEY (category_id) REFERENCES categories (id);
+
+CREATE INDEX IF NOT EXISTS idx_items_category_id ON items (category_id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION item_snapshot(item items)
+RETURNS JSONB AS $func$
+BEGIN
+    RETURN jsonb_build_object(
+        'id', item.id,
+        'sku', item.sku,
+        'barcodes', to_jsonb(item.barcodes),
+        'name', item.name,
+        'description', item.description,
+        'category_id', item.category_id,
+        'category_name', (SELECT name FROM categories WHERE id = item.category_id),
+        'quantity', item.quantity,
+        'price', item.price,
+        'version', item.version,
+        'created_at', item.created_at,
+        'updated_at', item.updated_at,
+        'deleted_at', item.deleted_at
+    );
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION item_snapshot(item items)
+RETURNS JSONB AS $func$
+BEGIN
+    RETURN jsonb_build_object(
+        'id', item.id,
+        'sku', item.sku,
+        'barcodes', to_jsonb(item.barcodes),
+        'name', item.name,
+        'description', item.description,
+        'quantity', item.quantity,
+        'price', item.price,
+        'version', item.version,
+        'created_at', item.created_at,
+        'updated_at', item.updated_at,
+        'deleted_at', item.deleted_at
+    );
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS idx_items_category_id;
+ALTER TABLE items DROP CONSTRAINT IF EXISTS items_category_id_fkey;
+ALTER TABLE items DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,61 @@
+-- +goose Up
+ALTER TABLE items ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE items ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_items_attributes ON items USING GIN (attributes);
+CREATE INDEX IF NOT EXISTS idx_items_tags ON items USING GIN (tags);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION item_snapshot(item items)
+RETURNS JSONB AS $func$
+BEGIN
+    RETURN jsonb_build_object(
+        'id', item.id,
+        'sku', item.sku,
+        'barcodes', to_jsonb(item.barcodes),
+        'name', item.name,
+        'description', item.description,
+        'category_id', item.category_id,
+        'category_name', (SELECT name FROM categories WHERE id = item.category_id),
+        'attributes', item.attributes,
+        'tags', to_jsonb(item.tags),
+        'quantity', item.quantity,
+        'price', item.price,
+        'version', item.version,
+        'created_at', item.created_at,
+        'updated_at', item.updated_at,
+        'deleted_at', item.deleted_at
+    );
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION item_snapshot(item items)
+RETURNS JSONB AS $func$
+BEGIN
+    RETURN jsonb_build_object(
+        'id', item.id,
+        'sku', item.sku,
+        'barcodes', to_jsonb(item.barcodes),
+        'name', item.name,
+        'description', item.description,
+        'category_id', item.category_id,
+        'category_name', (SELECT name FROM categories WHERE id = item.category_id),
+        'quantity', item.quantity,
+        'price', item.price,
+        'version', item.version,
+        'created_at', item.created_at,
+        'updated_at', item.updated_at,
+        'deleted_at', item.deleted_at
+    );
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS idx_items_tags;
+DROP INDEX IF EXISTS idx_items_attributes;
+
+ALTER TABLE items DROP COLUMN IF EXISTS tags;
+ALTER TABLE items DROP COLUMN IF EXISTS attributes;
@@ -0,0 +1,78 @@
+-- +goose Up
+ALTER TABLE items ADD COLUMN IF NOT EXISTS reorder_point INT CHECK (reorder_point >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_items_low_stock ON items (id)
+    WHERE deleted_at IS NULL AND reorder_point IS NOT NULL AND quantity <= reorder_point;
+
+CREATE TABLE IF NOT EXISTS alerts
+(
+    id              UUID PRIMARY KEY,
+    item_id         UUID        NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    kind            VARCHAR(32) NOT NULL,
+    quantity        INT         NOT NULL,
+    reorder_point   INT         NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    acknowledged_at TIMESTAMPTZ,
+    acknowledged_by UUID
+);
+
+-- Неподтверждённые оповещения выбираются чаще всего
+CREATE INDEX IF NOT EXISTS idx_alerts_open ON alerts (created_at DESC) WHERE acknowledged_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_alerts_item_id ON alerts (item_id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION item_snapshot(item items)
+RETURNS JSONB AS $func$
+BEGIN
+    RETURN jsonb_build_object(
+        'id', item.id,
+        'sku', item.sku,
+        'barcodes', to_jsonb(item.barcodes),
+        'name', item.name,
+        'description', item.description,
+        'category_id', item.category_id,
+        'category_name', (SELECT name FROM categories WHERE id = item.category_id),
+        'attributes', item.attributes,
+        'tags', to_jsonb(item.tags),
+        'reorder_point', item.reorder_point,
+        'quantity', item.quantity,
+        'price', item.price,
+        'version', item.version,
+        'created_at', item.created_at,
+        'updated_at', item.updated_at,
+        'deleted_at', item.deleted_at
+    );
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION item_snapshot(item items)
+RETURNS JSONB AS $func$
+BEGIN
+    RETURN jsonb_build_object(
+        'id', item.id,
+        'sku', item.sku,
+        'barcodes', to_jsonb(item.barcodes),
+        'name', item.name,
+        'description', item.description,
+        'category_id', item.category_id,
+        'category_name', (SELECT name FROM categories WHERE id = item.category_id),
+        'attributes', item.attributes,
+        'tags', to_jsonb(item.tags),
+        'quantity', item.quantity,
+        'price', item.price,
+        'version', item.version,
+        'created_at', item.created_at,
+        'updated_at', item.updated_at,
+        'deleted_at', item.deleted_at
+    );
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TABLE IF EXISTS alerts;
+
+DROP INDEX IF EXISTS idx_items_low_stock;
+ALTER TABLE items DROP COLUMN IF EXISTS reorder_point;
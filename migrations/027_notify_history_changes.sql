@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_history_change()
+RETURNS TRIGGER AS $func$
+BEGIN
+    -- В уведомлении только ID: размер payload ограничен 8000 байт
+    PERFORM pg_notify('items_history', NEW.id::TEXT);
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER items_history_notify_trigger
+    AFTER INSERT ON items_history
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_history_change();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS items_history_notify_trigger ON items_history;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS notify_history_change();
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+ALTER TABLE items_history ADD COLUMN IF NOT EXISTS reason TEXT;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fill_history_reason()
+RETURNS TRIGGER AS $func$
+BEGIN
+    -- Код и комментарий приходят из транзакции; код корректировки остатка уже заполнен триггером товара
+    NEW.reason_code := COALESCE(NEW.reason_code, NULLIF(current_setting('app.reason_code', true), ''));
+    NEW.reason := COALESCE(NEW.reason, NULLIF(current_setting('app.reason', true), ''));
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER items_history_reason_trigger
+    BEFORE INSERT ON items_history
+    FOR EACH ROW
+    EXECUTE FUNCTION fill_history_reason();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS items_history_reason_trigger ON items_history;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS fill_history_reason();
+-- +goose StatementEnd
+
+ALTER TABLE items_history DROP COLUMN IF EXISTS reason;
@@ -0,0 +1,30 @@
+-- +goose Up
+ALTER TABLE items_history ADD COLUMN IF NOT EXISTS request_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_history_request_id ON items_history (request_id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fill_history_request_id()
+RETURNS TRIGGER AS $func$
+BEGIN
+    -- X-Request-ID запроса, в рамках которого изменён товар
+    NEW.request_id := COALESCE(NEW.request_id, NULLIF(current_setting('app.request_id', true), ''));
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER items_history_request_id_trigger
+    BEFORE INSERT ON items_history
+    FOR EACH ROW
+    EXECUTE FUNCTION fill_history_request_id();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS items_history_request_id_trigger ON items_history;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS fill_history_request_id();
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS idx_history_request_id;
+
+ALTER TABLE items_history DROP COLUMN IF EXISTS request_id;
@@ -0,0 +1,117 @@
+-- +goose Up
+CREATE SEQUENCE IF NOT EXISTS items_history_chain_seq;
+
+ALTER TABLE items_history
+    ADD COLUMN IF NOT EXISTS chain_seq BIGINT,
+    ADD COLUMN IF NOT EXISTS prev_hash TEXT,
+    ADD COLUMN IF NOT EXISTS hash      TEXT;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION history_chain_field(value TEXT)
+RETURNS TEXT AS $func$
+    -- Длина перед значением исключает неоднозначность склейки; NULL отличается от пустой строки
+    SELECT CASE WHEN value IS NULL THEN '-' ELSE octet_length(value)::TEXT || ':' || value END;
+$func$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION history_chain_hash(entry items_history)
+RETURNS TEXT AS $func$
+    SELECT encode(sha256(convert_to(
+        history_chain_field(entry.chain_seq::TEXT) ||
+        history_chain_field(entry.prev_hash) ||
+        history_chain_field(entry.id::TEXT) ||
+        history_chain_field(entry.item_id::TEXT) ||
+        history_chain_field(entry.action::TEXT) ||
+        history_chain_field(entry.user_id::TEXT) ||
+        history_chain_field((EXTRACT(EPOCH FROM entry.changed_at) * 1000000)::BIGINT::TEXT) ||
+        history_chain_field(entry.old_data::TEXT) ||
+        history_chain_field(entry.new_data::TEXT) ||
+        history_chain_field(entry.quantity_delta::TEXT) ||
+        history_chain_field(entry.reason_code) ||
+        history_chain_field(entry.reference) ||
+        history_chain_field(entry.warehouse_id::TEXT) ||
+        history_chain_field(entry.location_id::TEXT) ||
+        history_chain_field(entry.reverted_from::TEXT) ||
+        history_chain_field(entry.reason) ||
+        history_chain_field(entry.request_id),
+        'UTF8'
+    )), 'hex');
+$func$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- Существующие записи выстраиваются в цепочку в порядке changed_at
+UPDATE items_history h
+SET chain_seq = o.seq
+FROM (SELECT id, row_number() OVER (ORDER BY changed_at, id) AS seq FROM items_history) o
+WHERE h.id = o.id;
+
+SELECT setval('items_history_chain_seq', COALESCE(MAX(chain_seq), 0) + 1, false) FROM items_history;
+
+-- +goose StatementBegin
+DO $block$
+DECLARE
+    entry     items_history;
+    last_hash TEXT;
+BEGIN
+    FOR entry IN SELECT * FROM items_history ORDER BY chain_seq LOOP
+        entry.prev_hash := last_hash;
+        last_hash := history_chain_hash(entry);
+
+        UPDATE items_history
+        SET prev_hash = entry.prev_hash,
+            hash      = last_hash
+        WHERE id = entry.id;
+    END LOOP;
+END;
+$block$;
+-- +goose StatementEnd
+
+ALTER TABLE items_history
+    ALTER COLUMN chain_seq SET NOT NULL,
+    ALTER COLUMN hash SET NOT NULL,
+    ADD CONSTRAINT items_history_chain_seq_key UNIQUE (chain_seq);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION seal_history_entry()
+RETURNS TRIGGER AS $func$
+BEGIN
+    -- Записи истории встают в цепочку по одной: блокировка держится до конца транзакции
+    PERFORM pg_advisory_xact_lock(hashtext('items_history_chain'));
+
+    NEW.chain_seq := nextval('items_history_chain_seq');
+    SELECT hash INTO NEW.prev_hash FROM items_history ORDER BY chain_seq DESC LIMIT 1;
+    NEW.hash := history_chain_hash(NEW);
+
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- BEFORE-триггеры срабатывают по алфавиту: печать ставится после заполнения reason и request_id
+CREATE TRIGGER items_history_seal_trigger
+    BEFORE INSERT ON items_history
+    FOR EACH ROW
+    EXECUTE FUNCTION seal_history_entry();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS items_history_seal_trigger ON items_history;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS seal_history_entry();
+-- +goose StatementEnd
+
+ALTER TABLE items_history DROP CONSTRAINT IF EXISTS items_history_chain_seq_key;
+
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS history_chain_hash(items_history);
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS history_chain_field(TEXT);
+-- +goose StatementEnd
+
+ALTER TABLE items_history
+    DROP COLUMN IF EXISTS hash,
+    DROP COLUMN IF EXISTS prev_hash,
+    DROP COLUMN IF EXISTS chain_seq;
+
+DROP SEQUENCE IF EXISTS items_history_chain_seq;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Пользователи, созданные до появления паролей, не могут войти, пока администратор не задаст пароль
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash TEXT;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS password_hash;
@@ -0,0 +1,100 @@
+-- +goose Up
+-- Пользователи не удаляются физически: items_history.user_id ссылается на users с ON DELETE SET NULL,
+-- и удаление строки переписало бы историю товаров и сломало цепочку хешей
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_at  TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS users_history
+(
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID        NOT NULL REFERENCES users (id),
+    action      VARCHAR(32) NOT NULL CHECK (action IN ('create', 'role_change', 'disable', 'enable', 'password_reset', 'delete')),
+    changed_by  UUID REFERENCES users (id),
+    changed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    old_data    JSONB,
+    new_data    JSONB,
+    reason_code TEXT,
+    reason      TEXT,
+    request_id  TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_history_user_id ON users_history (user_id, changed_at);
+CREATE INDEX IF NOT EXISTS idx_users_history_changed_by ON users_history (changed_by);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_user_changes()
+RETURNS TRIGGER AS $func$
+DECLARE
+    actor_uuid  UUID;
+    old_json    JSONB;
+    new_json    JSONB;
+    actions     TEXT[] := '{}';
+    user_action TEXT;
+BEGIN
+    BEGIN
+        actor_uuid := NULLIF(current_setting('app.user_id', true), '')::UUID;
+    EXCEPTION WHEN OTHERS THEN
+        actor_uuid := NULL;
+    END;
+
+    -- Хеш пароля в аудит не попадает
+    new_json := to_jsonb(NEW) - 'password_hash';
+
+    IF TG_OP = 'INSERT' THEN
+        actions := ARRAY['create'];
+    ELSE
+        old_json := to_jsonb(OLD) - 'password_hash';
+
+        IF NEW.deleted_at IS NOT NULL AND OLD.deleted_at IS NULL THEN
+            actions := ARRAY['delete'];
+        ELSE
+            IF NEW.role IS DISTINCT FROM OLD.role THEN
+                actions := actions || 'role_change'::TEXT;
+            END IF;
+            IF NEW.disabled_at IS NOT NULL AND OLD.disabled_at IS NULL THEN
+                actions := actions || 'disable'::TEXT;
+            ELSIF NEW.disabled_at IS NULL AND OLD.disabled_at IS NOT NULL THEN
+                actions := actions || 'enable'::TEXT;
+            END IF;
+            IF NEW.password_hash IS DISTINCT FROM OLD.password_hash THEN
+                actions := actions || 'password_reset'::TEXT;
+            END IF;
+        END IF;
+    END IF;
+
+    FOREACH user_action IN ARRAY actions LOOP
+        INSERT INTO users_history (user_id, action, changed_by, old_data, new_data, reason_code, reason, request_id)
+        VALUES (
+            NEW.id,
+            user_action,
+            actor_uuid,
+            old_json,
+            new_json,
+            NULLIF(current_setting('app.reason_code', true), ''),
+            NULLIF(current_setting('app.reason', true), ''),
+            NULLIF(current_setting('app.request_id', true), '')
+        );
+    END LOOP;
+
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER users_history_trigger
+    AFTER INSERT OR UPDATE ON users
+    FOR EACH ROW
+    EXECUTE FUNCTION log_user_changes();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS users_history_trigger ON users;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS log_user_changes();
+-- +goose StatementEnd
+
+DROP TABLE IF EXISTS users_history;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS disabled_at;
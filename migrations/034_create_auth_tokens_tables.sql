@@ -0,0 +1,32 @@
+-- +goose Up
+-- Refresh-токены хранятся только в виде SHA-256; токены одной цепочки ротации объединяет family_id
+CREATE TABLE IF NOT EXISTS refresh_tokens
+(
+    id          UUID PRIMARY KEY,
+    user_id     UUID        NOT NULL REFERENCES users (id),
+    family_id   UUID        NOT NULL,
+    token_hash  TEXT        NOT NULL UNIQUE,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at  TIMESTAMPTZ,
+    replaced_by UUID REFERENCES refresh_tokens (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id) WHERE revoked_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON refresh_tokens (expires_at);
+
+-- Отозванные access-токены по jti; строку можно удалить после истечения срока токена
+CREATE TABLE IF NOT EXISTS revoked_tokens
+(
+    jti        UUID PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users (id),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
@@ -0,0 +1,114 @@
+-- +goose Up
+-- Ключ показывается один раз при создании; в базе хранятся только SHA-256 и префикс для опознания
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id           UUID PRIMARY KEY,
+    name         VARCHAR(64) NOT NULL,
+    role         VARCHAR(32) NOT NULL CHECK (role IN ('admin', 'manager', 'viewer')),
+    key_prefix   VARCHAR(16) NOT NULL,
+    key_hash     TEXT        NOT NULL UNIQUE,
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    created_by   UUID REFERENCES users (id),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at   TIMESTAMPTZ
+);
+
+ALTER TABLE items_history ADD COLUMN IF NOT EXISTS api_key_id UUID REFERENCES api_keys (id);
+ALTER TABLE users_history ADD COLUMN IF NOT EXISTS api_key_id UUID REFERENCES api_keys (id);
+
+CREATE INDEX IF NOT EXISTS idx_history_api_key_id ON items_history (api_key_id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fill_history_api_key()
+RETURNS TRIGGER AS $func$
+BEGIN
+    -- API-ключ, через который выполнен запрос; заполняется вместо app.user_id
+    BEGIN
+        NEW.api_key_id := COALESCE(NEW.api_key_id, NULLIF(current_setting('app.api_key_id', true), '')::UUID);
+    EXCEPTION WHEN OTHERS THEN
+        NEW.api_key_id := NULL;
+    END;
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- Срабатывает раньше items_history_seal_trigger, поэтому api_key_id попадает в хеш записи
+CREATE TRIGGER items_history_api_key_trigger
+    BEFORE INSERT ON items_history
+    FOR EACH ROW
+    EXECUTE FUNCTION fill_history_api_key();
+
+CREATE TRIGGER users_history_api_key_trigger
+    BEFORE INSERT ON users_history
+    FOR EACH ROW
+    EXECUTE FUNCTION fill_history_api_key();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION history_chain_hash(entry items_history)
+RETURNS TEXT AS $func$
+    -- api_key_id дописывается только если заполнен: хеши записей до появления ключей не меняются
+    SELECT encode(sha256(convert_to(
+        history_chain_field(entry.chain_seq::TEXT) ||
+        history_chain_field(entry.prev_hash) ||
+        history_chain_field(entry.id::TEXT) ||
+        history_chain_field(entry.item_id::TEXT) ||
+        history_chain_field(entry.action::TEXT) ||
+        history_chain_field(entry.user_id::TEXT) ||
+        history_chain_field((EXTRACT(EPOCH FROM entry.changed_at) * 1000000)::BIGINT::TEXT) ||
+        history_chain_field(entry.old_data::TEXT) ||
+        history_chain_field(entry.new_data::TEXT) ||
+        history_chain_field(entry.quantity_delta::TEXT) ||
+        history_chain_field(entry.reason_code) ||
+        history_chain_field(entry.reference) ||
+        history_chain_field(entry.warehouse_id::TEXT) ||
+        history_chain_field(entry.location_id::TEXT) ||
+        history_chain_field(entry.reverted_from::TEXT) ||
+        history_chain_field(entry.reason) ||
+        history_chain_field(entry.request_id) ||
+        CASE WHEN entry.api_key_id IS NULL THEN '' ELSE history_chain_field(entry.api_key_id::TEXT) END,
+        'UTF8'
+    )), 'hex');
+$func$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION history_chain_hash(entry items_history)
+RETURNS TEXT AS $func$
+    SELECT encode(sha256(convert_to(
+        history_chain_field(entry.chain_seq::TEXT) ||
+        history_chain_field(entry.prev_hash) ||
+        history_chain_field(entry.id::TEXT) ||
+        history_chain_field(entry.item_id::TEXT) ||
+        history_chain_field(entry.action::TEXT) ||
+        history_chain_field(entry.user_id::TEXT) ||
+        history_chain_field((EXTRACT(EPOCH FROM entry.changed_at) * 1000000)::BIGINT::TEXT) ||
+        history_chain_field(entry.old_data::TEXT) ||
+        history_chain_field(entry.new_data::TEXT) ||
+        history_chain_field(entry.quantity_delta::TEXT) ||
+        history_chain_field(entry.reason_code) ||
+        history_chain_field(entry.reference) ||
+        history_chain_field(entry.warehouse_id::TEXT) ||
+        history_chain_field(entry.location_id::TEXT) ||
+        history_chain_field(entry.reverted_from::TEXT) ||
+        history_chain_field(entry.reason) ||
+        history_chain_field(entry.request_id),
+        'UTF8'
+    )), 'hex');
+$func$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS users_history_api_key_trigger ON users_history;
+DROP TRIGGER IF EXISTS items_history_api_key_trigger ON items_history;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS fill_history_api_key();
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS idx_history_api_key_id;
+
+ALTER TABLE users_history DROP COLUMN IF EXISTS api_key_id;
+ALTER TABLE items_history DROP COLUMN IF EXISTS api_key_id;
+
+DROP TABLE IF EXISTS api_keys;
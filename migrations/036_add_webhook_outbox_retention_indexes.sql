@@ -0,0 +1,8 @@
+-- +goose Up
+-- Очистка outbox ищет обработанные события по processed_at и проверяет их доставки по event_id
+CREATE INDEX IF NOT EXISTS idx_webhook_outbox_processed_at ON webhook_outbox (processed_at) WHERE processed_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries (event_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_webhook_deliveries_event_id;
+DROP INDEX IF EXISTS idx_webhook_outbox_processed_at;
@@ -0,0 +1,55 @@
+-- +goose Up
+-- Уровень остатка для оповещений: NULL - в норме, в архиве или без точки заказа
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION stock_alert_kind(quantity INT, reorder_point INT, deleted_at TIMESTAMPTZ)
+RETURNS TEXT AS $func$
+    SELECT CASE
+        WHEN deleted_at IS NOT NULL OR reorder_point IS NULL OR quantity > reorder_point THEN NULL
+        WHEN quantity = 0 THEN 'out_of_stock'
+        ELSE 'low_stock'
+    END;
+$func$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- Оповещение пишется в той же транзакции, что и изменение остатка, по OLD/NEW под блокировкой строки
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION raise_stock_alert()
+RETURNS TRIGGER AS $func$
+DECLARE
+    new_kind TEXT;
+    old_kind TEXT;
+BEGIN
+    new_kind := stock_alert_kind(NEW.quantity, NEW.reorder_point, NEW.deleted_at);
+    IF new_kind IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP = 'UPDATE' THEN
+        old_kind := stock_alert_kind(OLD.quantity, OLD.reorder_point, OLD.deleted_at);
+        -- Оповещаем только об ухудшении: норма -> low_stock/out_of_stock, low_stock -> out_of_stock
+        IF old_kind = new_kind OR old_kind = 'out_of_stock' THEN
+            RETURN NULL;
+        END IF;
+    END IF;
+
+    INSERT INTO alerts (id, item_id, kind, quantity, reorder_point, created_at)
+    VALUES (gen_random_uuid(), NEW.id, new_kind, NEW.quantity, NEW.reorder_point, NOW());
+
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER items_stock_alert_trigger
+    AFTER INSERT OR UPDATE OF quantity, reorder_point, deleted_at ON items
+    FOR EACH ROW
+    EXECUTE FUNCTION raise_stock_alert();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS items_stock_alert_trigger ON items;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS raise_stock_alert();
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS stock_alert_kind(INT, INT, TIMESTAMPTZ);
+-- +goose StatementEnd
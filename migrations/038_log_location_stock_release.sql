@@ -0,0 +1,110 @@
+-- +goose Up
+ALTER TYPE item_status ADD VALUE IF NOT EXISTS 'release';
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION release_location_stock()
+RETURNS TRIGGER AS $func$
+DECLARE
+    excess INT;
+    take INT;
+    location_rec RECORD;
+    prev_action TEXT;
+BEGIN
+    IF NEW.quantity >= OLD.quantity THEN
+        RETURN NEW;
+    END IF;
+
+    SELECT COALESCE(SUM(ls.quantity), 0) - NEW.quantity
+    INTO excess
+    FROM location_stocks ls
+             JOIN storage_locations l ON l.id = ls.location_id
+    WHERE ls.item_id = NEW.item_id
+      AND l.warehouse_id = NEW.warehouse_id;
+
+    IF excess <= 0 THEN
+        RETURN NEW;
+    END IF;
+
+    -- Списание с ячеек пишется в items_history триггером location_stocks_history_trigger с действием release
+    prev_action := current_setting('app.location_action', true);
+    PERFORM set_config('app.location_action', 'release', true);
+
+    FOR location_rec IN
+        SELECT ls.location_id, ls.quantity
+        FROM location_stocks ls
+                 JOIN storage_locations l ON l.id = ls.location_id
+        WHERE ls.item_id = NEW.item_id
+          AND l.warehouse_id = NEW.warehouse_id
+          AND ls.quantity > 0
+        ORDER BY l.code
+        FOR UPDATE OF ls
+    LOOP
+        take := LEAST(location_rec.quantity, excess);
+
+        UPDATE location_stocks
+        SET quantity = quantity - take,
+            updated_at = NOW()
+        WHERE item_id = NEW.item_id
+          AND location_id = location_rec.location_id;
+
+        excess := excess - take;
+        EXIT WHEN excess = 0;
+    END LOOP;
+
+    PERFORM set_config('app.location_action', COALESCE(prev_action, ''), true);
+
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION release_location_stock()
+RETURNS TRIGGER AS $func$
+DECLARE
+    excess INT;
+    take INT;
+    location_rec RECORD;
+BEGIN
+    IF NEW.quantity >= OLD.quantity THEN
+        RETURN NEW;
+    END IF;
+
+    SELECT COALESCE(SUM(ls.quantity), 0) - NEW.quantity
+    INTO excess
+    FROM location_stocks ls
+             JOIN storage_locations l ON l.id = ls.location_id
+    WHERE ls.item_id = NEW.item_id
+      AND l.warehouse_id = NEW.warehouse_id;
+
+    IF excess <= 0 THEN
+        RETURN NEW;
+    END IF;
+
+    FOR location_rec IN
+        SELECT ls.location_id, ls.quantity
+        FROM location_stocks ls
+                 JOIN storage_locations l ON l.id = ls.location_id
+        WHERE ls.item_id = NEW.item_id
+          AND l.warehouse_id = NEW.warehouse_id
+          AND ls.quantity > 0
+        ORDER BY l.code
+        FOR UPDATE OF ls
+    LOOP
+        take := LEAST(location_rec.quantity, excess);
+
+        UPDATE location_stocks
+        SET quantity = quantity - take,
+            updated_at = NOW()
+        WHERE item_id = NEW.item_id
+          AND location_id = location_rec.location_id;
+
+        excess := excess - take;
+        EXIT WHEN excess = 0;
+    END LOOP;
+
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Оповещения и события вебхуков, созданные запросом с API-ключом, привязываются к ключу так же, как история
+ALTER TABLE alerts ADD COLUMN IF NOT EXISTS api_key_id UUID REFERENCES api_keys (id);
+ALTER TABLE alerts ADD COLUMN IF NOT EXISTS acknowledged_api_key_id UUID REFERENCES api_keys (id);
+ALTER TABLE webhook_outbox ADD COLUMN IF NOT EXISTS api_key_id UUID REFERENCES api_keys (id);
+
+CREATE TRIGGER alerts_api_key_trigger
+    BEFORE INSERT ON alerts
+    FOR EACH ROW
+    EXECUTE FUNCTION fill_history_api_key();
+
+CREATE TRIGGER webhook_outbox_api_key_trigger
+    BEFORE INSERT ON webhook_outbox
+    FOR EACH ROW
+    EXECUTE FUNCTION fill_history_api_key();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS webhook_outbox_api_key_trigger ON webhook_outbox;
+DROP TRIGGER IF EXISTS alerts_api_key_trigger ON alerts;
+
+ALTER TABLE webhook_outbox DROP COLUMN IF EXISTS api_key_id;
+ALTER TABLE alerts DROP COLUMN IF EXISTS acknowledged_api_key_id;
+ALTER TABLE alerts DROP COLUMN IF EXISTS api_key_id;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fill_history_reason()
+RETURNS TRIGGER AS $func$
+BEGIN
+    -- Код из запроса важнее вида корректировки, который триггер товара пишет в reason_code по умолчанию
+    NEW.reason_code := COALESCE(NULLIF(current_setting('app.reason_code', true), ''), NEW.reason_code);
+    NEW.reason := COALESCE(NEW.reason, NULLIF(current_setting('app.reason', true), ''));
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fill_history_reason()
+RETURNS TRIGGER AS $func$
+BEGIN
+    -- Код и комментарий приходят из транзакции; код корректировки остатка уже заполнен триггером товара
+    NEW.reason_code := COALESCE(NEW.reason_code, NULLIF(current_setting('app.reason_code', true), ''));
+    NEW.reason := COALESCE(NEW.reason, NULLIF(current_setting('app.reason', true), ''));
+    RETURN NEW;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Корзина листается страницами по времени архивации
+CREATE INDEX IF NOT EXISTS idx_items_trash ON items (deleted_at DESC, id DESC) WHERE deleted_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_items_trash;
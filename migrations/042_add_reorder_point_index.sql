@@ -0,0 +1,12 @@
+-- +goose Up
+-- Индекс повторяет сортировку списка заканчивающихся товаров, чтобы страницы читались без полного сканирования
+CREATE INDEX IF NOT EXISTS idx_items_reorder_point ON items ((reorder_point - quantity) DESC, name, id)
+    WHERE deleted_at IS NULL AND reorder_point IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_items_low_stock;
+
+-- +goose Down
+CREATE INDEX IF NOT EXISTS idx_items_low_stock ON items (id)
+    WHERE deleted_at IS NULL AND reorder_point IS NOT NULL AND quantity <= reorder_point;
+
+DROP INDEX IF EXISTS idx_items_reorder_point;
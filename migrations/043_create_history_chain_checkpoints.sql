@@ -0,0 +1,11 @@
+-- +goose Up
+-- Голова цепочки после каждой успешной проверки: удаление записей с конца будет видно при следующей проверке
+CREATE TABLE IF NOT EXISTS history_chain_checkpoints
+(
+    chain_seq  BIGINT PRIMARY KEY,
+    hash       TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS history_chain_checkpoints;
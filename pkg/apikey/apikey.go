@@ -0,0 +1,24 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	Header       = "X-API-Key"
+	Prefix       = "wbk_"
+	PrefixLength = 12
+)
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(contextKey{}).(uuid.UUID)
+	return id, ok
+}
@@ -0,0 +1,28 @@
+package hashchain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+const nullField = "-"
+
+func Field(value *string) string {
+	if value == nil {
+		return nullField
+	}
+
+	return strconv.Itoa(len(*value)) + ":" + *value
+}
+
+func Hash(fields ...*string) string {
+	var payload strings.Builder
+	for _, field := range fields {
+		payload.WriteString(Field(field))
+	}
+
+	sum := sha256.Sum256([]byte(payload.String()))
+	return hex.EncodeToString(sum[:])
+}
@@ -32,7 +32,7 @@ type Claims struct {
 func GenerateToken(
 	userID uuid.UUID,
 	role Role,
-	secret []byte,
+	keys *KeySet,
 	ttl time.Duration,
 	issuer string,
 ) (string, error) {
@@ -42,19 +42,24 @@ func GenerateToken(
 		UserID: userID,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Issuer:    issuer,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(secret)
+	token := jwt.NewWithClaims(keys.signingMethod, claims)
+	if keys.signingID != "" {
+		token.Header["kid"] = keys.signingID
+	}
+
+	return token.SignedString(keys.signingKey)
 }
 
 func ParseToken(
 	tokenString string,
-	secret []byte,
+	keys *KeySet,
 	issuer string,
 ) (*Claims, error) {
 	claims := &Claims{}
@@ -62,14 +67,9 @@ func ParseToken(
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		claims,
-		func(token *jwt.Token) (any, error) {
-			if token.Method != jwt.SigningMethodHS256 {
-				return nil, ErrInvalidToken
-			}
-			return secret, nil
-		},
+		keys.lookup,
 		jwt.WithIssuer(issuer),
-		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithValidMethods(keys.methods()),
 	)
 
 	if err != nil {
@@ -0,0 +1,234 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const keyIDLength = 16
+
+var (
+	ErrUnsupportedKey = errors.New("unsupported key type: expected RSA or Ed25519")
+	ErrInvalidPEM     = errors.New("no PEM block found")
+)
+
+type verificationKey struct {
+	id     string
+	method jwt.SigningMethod
+	key    any
+	public crypto.PublicKey
+}
+
+type KeySet struct {
+	signingID     string
+	signingMethod jwt.SigningMethod
+	signingKey    any
+	verification  map[string]*verificationKey
+}
+
+type JSONWebKey struct {
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+func NewHMACKeySet(secret string) *KeySet {
+	return &KeySet{
+		signingMethod: jwt.SigningMethodHS256,
+		signingKey:    []byte(secret),
+		verification: map[string]*verificationKey{
+			"": {method: jwt.SigningMethodHS256, key: []byte(secret)},
+		},
+	}
+}
+
+func LoadKeySet(signingKeyFile string, verificationKeyFiles []string) (*KeySet, error) {
+	signer, err := loadPrivateKey(signingKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("signing key %s: %w", signingKeyFile, err)
+	}
+
+	signing, err := newVerificationKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("signing key %s: %w", signingKeyFile, err)
+	}
+
+	set := &KeySet{
+		signingID:     signing.id,
+		signingMethod: signing.method,
+		signingKey:    signer,
+		verification:  map[string]*verificationKey{signing.id: signing},
+	}
+
+	for _, path := range verificationKeyFiles {
+		public, errLoad := loadPublicKey(path)
+		if errLoad != nil {
+			return nil, fmt.Errorf("verification key %s: %w", path, errLoad)
+		}
+
+		key, errKey := newVerificationKey(public)
+		if errKey != nil {
+			return nil, fmt.Errorf("verification key %s: %w", path, errKey)
+		}
+		set.verification[key.id] = key
+	}
+
+	return set, nil
+}
+
+func (s *KeySet) JWKS() JSONWebKeySet {
+	jwks := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range s.verification {
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwks.Keys = append(jwks.Keys, JSONWebKey{
+				KeyID:     key.id,
+				KeyType:   "RSA",
+				Algorithm: key.method.Alg(),
+				Use:       "sig",
+				N:         base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+				E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			jwks.Keys = append(jwks.Keys, JSONWebKey{
+				KeyID:     key.id,
+				KeyType:   "OKP",
+				Algorithm: key.method.Alg(),
+				Use:       "sig",
+				Curve:     "Ed25519",
+				X:         base64.RawURLEncoding.EncodeToString(public),
+			})
+		}
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID
+	})
+
+	return jwks
+}
+
+func (s *KeySet) methods() []string {
+	seen := make(map[string]struct{})
+	var methods []string
+	for _, key := range s.verification {
+		if _, ok := seen[key.method.Alg()]; !ok {
+			seen[key.method.Alg()] = struct{}{}
+			methods = append(methods, key.method.Alg())
+		}
+	}
+
+	return methods
+}
+
+func (s *KeySet) lookup(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := s.verification[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown kid %q", ErrInvalidToken, kid)
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, ErrInvalidToken
+	}
+
+	return key.key, nil
+}
+
+func newVerificationKey(public crypto.PublicKey) (*verificationKey, error) {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(der)
+	key := &verificationKey{
+		id:     hex.EncodeToString(sum[:])[:keyIDLength],
+		key:    public,
+		public: public,
+	}
+
+	switch public.(type) {
+	case *rsa.PublicKey:
+		key.method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, ErrUnsupportedKey
+	}
+
+	return key, nil
+}
+
+func loadPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedKey
+	}
+
+	return signer, nil
+}
+
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	return block, nil
+}
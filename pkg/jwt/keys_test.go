@@ -0,0 +1,249 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const testIssuer = "wb-warehouse-control"
+
+type testKey struct {
+	signer      crypto.Signer
+	privatePath string
+	publicPath  string
+}
+
+func writeTestKey(t *testing.T, name string, signer crypto.Signer) testKey {
+	t.Helper()
+
+	private, err := x509.MarshalPKCS8PrivateKey(signer)
+	if err != nil {
+		t.Fatalf("marshal private key: %v", err)
+	}
+
+	public, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+
+	dir := t.TempDir()
+	key := testKey{
+		signer:      signer,
+		privatePath: filepath.Join(dir, name+".pem"),
+		publicPath:  filepath.Join(dir, name+".pub.pem"),
+	}
+
+	writePEM(t, key.privatePath, "PRIVATE KEY", private)
+	writePEM(t, key.publicPath, "PUBLIC KEY", public)
+
+	return key
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func newRSATestKey(t *testing.T) testKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+
+	return writeTestKey(t, "rsa", key)
+}
+
+func newEd25519TestKey(t *testing.T) testKey {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ed25519 key: %v", err)
+	}
+
+	return writeTestKey(t, "ed25519", key)
+}
+
+func loadTestKeySet(t *testing.T, signing testKey, verification ...testKey) *KeySet {
+	t.Helper()
+
+	paths := make([]string, 0, len(verification))
+	for _, key := range verification {
+		paths = append(paths, key.publicPath)
+	}
+
+	set, err := LoadKeySet(signing.privatePath, paths)
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+
+	return set
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, kid string, key any) string {
+	t.Helper()
+
+	now := time.Now()
+	token := jwt.NewWithClaims(method, Claims{
+		UserID: uuid.New(),
+		Role:   RoleViewer,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    testIssuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+	})
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	return signed
+}
+
+func TestKeySetRotation(t *testing.T) {
+	oldKey := newRSATestKey(t)
+	newKey := newEd25519TestKey(t)
+
+	oldSet := loadTestKeySet(t, oldKey)
+	rotated := loadTestKeySet(t, newKey, oldKey)
+
+	userID := uuid.New()
+	oldToken, err := GenerateToken(userID, RoleManager, oldSet, time.Minute, testIssuer)
+	if err != nil {
+		t.Fatalf("GenerateToken old: %v", err)
+	}
+
+	newToken, err := GenerateToken(userID, RoleManager, rotated, time.Minute, testIssuer)
+	if err != nil {
+		t.Fatalf("GenerateToken new: %v", err)
+	}
+
+	for name, token := range map[string]string{"old key": oldToken, "new key": newToken} {
+		t.Run(name, func(t *testing.T) {
+			claims, errParse := ParseToken(token, rotated, testIssuer)
+			if errParse != nil {
+				t.Fatalf("ParseToken: %v", errParse)
+			}
+			if claims.UserID != userID || claims.Role != RoleManager {
+				t.Fatalf("claims = %+v, want user %s role %s", claims, userID, RoleManager)
+			}
+		})
+	}
+
+	if _, err = ParseToken(newToken, oldSet, testIssuer); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("new token with old key set: err = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestKeySetRejectsUnknownOrMismatchedKey(t *testing.T) {
+	rsaKey := newRSATestKey(t)
+	edKey := newEd25519TestKey(t)
+	set := loadTestKeySet(t, edKey, rsaKey)
+
+	rsaKID := loadTestKeySet(t, rsaKey).signingID
+	edKID := set.signingID
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{
+			name:  "unknown kid",
+			token: signTestToken(t, jwt.SigningMethodEdDSA, "0000000000000000", edKey.signer),
+		},
+		{
+			name:  "missing kid",
+			token: signTestToken(t, jwt.SigningMethodEdDSA, "", edKey.signer),
+		},
+		{
+			name:  "not in key set",
+			token: signTestToken(t, jwt.SigningMethodRS256, rsaKID, newRSATestKey(t).signer),
+		},
+		{
+			name:  "eddsa token with rsa kid",
+			token: signTestToken(t, jwt.SigningMethodEdDSA, rsaKID, edKey.signer),
+		},
+		{
+			name:  "rs256 token with ed25519 kid",
+			token: signTestToken(t, jwt.SigningMethodRS256, edKID, rsaKey.signer),
+		},
+		{
+			name:  "hmac token with rsa kid",
+			token: signTestToken(t, jwt.SigningMethodHS256, rsaKID, []byte("secret")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseToken(tt.token, set, testIssuer); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("ParseToken err = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestKeySetJWKS(t *testing.T) {
+	rsaKey := newRSATestKey(t)
+	edKey := newEd25519TestKey(t)
+
+	set := loadTestKeySet(t, edKey, rsaKey)
+	rsaKID := loadTestKeySet(t, rsaKey).signingID
+	rsaPublic := rsaKey.signer.Public().(*rsa.PublicKey)
+	edPublic := edKey.signer.Public().(ed25519.PublicKey)
+
+	want := map[string]JSONWebKey{
+		rsaKID: {
+			KeyID:     rsaKID,
+			KeyType:   "RSA",
+			Algorithm: "RS256",
+			Use:       "sig",
+			N:         base64.RawURLEncoding.EncodeToString(rsaPublic.N.Bytes()),
+			E:         "AQAB",
+		},
+		set.signingID: {
+			KeyID:     set.signingID,
+			KeyType:   "OKP",
+			Algorithm: "EdDSA",
+			Use:       "sig",
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(edPublic),
+		},
+	}
+
+	jwks := set.JWKS()
+	if len(jwks.Keys) != len(want) {
+		t.Fatalf("JWKS has %d keys, want %d", len(jwks.Keys), len(want))
+	}
+
+	for _, key := range jwks.Keys {
+		if key != want[key.KeyID] {
+			t.Fatalf("JWKS key = %+v, want %+v", key, want[key.KeyID])
+		}
+	}
+
+	if hmac := NewHMACKeySet("secret").JWKS(); len(hmac.Keys) != 0 {
+		t.Fatalf("HMAC JWKS has %d keys, want 0", len(hmac.Keys))
+	}
+}
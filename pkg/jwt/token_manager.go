@@ -8,30 +8,40 @@ import (
 
 type TokenGenerator interface {
 	GenerateToken(userID uuid.UUID, role Role) (string, error)
+	TTL() time.Duration
 }
 
 type TokenValidator interface {
 	ValidateToken(tokenString string) (*Claims, error)
+	JWKS() JSONWebKeySet
 }
 
 type Manager struct {
-	secret []byte
+	keys   *KeySet
 	ttl    time.Duration
 	issuer string
 }
 
-func NewJWTManager(secret string, ttl time.Duration, issuer string) *Manager {
+func NewJWTManager(keys *KeySet, ttl time.Duration, issuer string) *Manager {
 	return &Manager{
-		secret: []byte(secret),
+		keys:   keys,
 		ttl:    ttl,
 		issuer: issuer,
 	}
 }
 
 func (m *Manager) GenerateToken(userID uuid.UUID, role Role) (string, error) {
-	return GenerateToken(userID, role, m.secret, m.ttl, m.issuer)
+	return GenerateToken(userID, role, m.keys, m.ttl, m.issuer)
 }
 
 func (m *Manager) ValidateToken(tokenString string) (*Claims, error) {
-	return ParseToken(tokenString, m.secret, m.issuer)
+	return ParseToken(tokenString, m.keys, m.issuer)
+}
+
+func (m *Manager) JWKS() JSONWebKeySet {
+	return m.keys.JWKS()
+}
+
+func (m *Manager) TTL() time.Duration {
+	return m.ttl
 }
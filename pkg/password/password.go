@@ -0,0 +1,58 @@
+package password
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrMismatch = errors.New("password does not match")
+	ErrTooLong  = errors.New("password is too long")
+)
+
+type Hasher struct {
+	cost      int
+	dummyHash []byte
+}
+
+func NewHasher(cost int) (*Hasher, error) {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+
+	dummyHash, err := bcrypt.GenerateFromPassword([]byte("dummy-password"), cost)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Hasher{
+		cost:      cost,
+		dummyHash: dummyHash,
+	}, nil
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return "", ErrTooLong
+		}
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+func (h *Hasher) Compare(hash *string, password string) error {
+	if hash == nil {
+		_ = bcrypt.CompareHashAndPassword(h.dummyHash, []byte(password))
+		return ErrMismatch
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(*hash), []byte(password)); err != nil {
+		return ErrMismatch
+	}
+
+	return nil
+}
@@ -3,9 +3,16 @@ package validator
 type ActionType string
 
 const (
-	ActionCreate ActionType = "create"
-	ActionUpdate ActionType = "update"
-	ActionDelete ActionType = "delete"
+	ActionCreate   ActionType = "create"
+	ActionUpdate   ActionType = "update"
+	ActionDelete   ActionType = "delete"
+	ActionArchive  ActionType = "archive"
+	ActionRestore  ActionType = "restore"
+	ActionAdjust   ActionType = "adjust"
+	ActionTransfer ActionType = "transfer"
+	ActionPutaway  ActionType = "putaway"
+	ActionRelocate ActionType = "relocate"
+	ActionRelease  ActionType = "release"
 )
 
 type Role string
@@ -16,11 +23,47 @@ const (
 	RoleViewer  Role = "viewer"
 )
 
+type AdjustReason string
+
+const (
+	AdjustReasonReceipt    AdjustReason = "receipt"
+	AdjustReasonShipment   AdjustReason = "shipment"
+	AdjustReasonInventory  AdjustReason = "inventory"
+	AdjustReasonDamage     AdjustReason = "damage"
+	AdjustReasonReturn     AdjustReason = "return"
+	AdjustReasonCorrection AdjustReason = "correction"
+)
+
+type MovementKind string
+
+const (
+	MovementReceipt    MovementKind = "receipt"
+	MovementIssue      MovementKind = "issue"
+	MovementTransfer   MovementKind = "transfer"
+	MovementAdjustment MovementKind = "adjustment"
+	MovementReturn     MovementKind = "return"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookEventItemCreated WebhookEvent = "item.created"
+	WebhookEventItemUpdated WebhookEvent = "item.updated"
+	WebhookEventItemDeleted WebhookEvent = "item.deleted"
+)
+
 //nolint:gochecknoglobals // These are constant maps used for validation
 var AllowedActionTypes = map[ActionType]struct{}{
-	ActionCreate: {},
-	ActionUpdate: {},
-	ActionDelete: {},
+	ActionCreate:   {},
+	ActionUpdate:   {},
+	ActionDelete:   {},
+	ActionArchive:  {},
+	ActionRestore:  {},
+	ActionAdjust:   {},
+	ActionTransfer: {},
+	ActionPutaway:  {},
+	ActionRelocate: {},
+	ActionRelease:  {},
 }
 
 //nolint:gochecknoglobals // These are constant maps used for validation
@@ -29,3 +72,29 @@ var AllowedRoles = map[Role]struct{}{
 	RoleManager: {},
 	RoleViewer:  {},
 }
+
+//nolint:gochecknoglobals // These are constant maps used for validation
+var AllowedAdjustReasons = map[AdjustReason]struct{}{
+	AdjustReasonReceipt:    {},
+	AdjustReasonShipment:   {},
+	AdjustReasonInventory:  {},
+	AdjustReasonDamage:     {},
+	AdjustReasonReturn:     {},
+	AdjustReasonCorrection: {},
+}
+
+//nolint:gochecknoglobals // These are constant maps used for validation
+var AllowedMovementKinds = map[MovementKind]struct{}{
+	MovementReceipt:    {},
+	MovementIssue:      {},
+	MovementTransfer:   {},
+	MovementAdjustment: {},
+	MovementReturn:     {},
+}
+
+//nolint:gochecknoglobals // These are constant maps used for validation
+var AllowedWebhookEvents = map[WebhookEvent]struct{}{
+	WebhookEventItemCreated: {},
+	WebhookEventItemUpdated: {},
+	WebhookEventItemDeleted: {},
+}
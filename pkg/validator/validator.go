@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"regexp"
 	"strings"
 	"time"
 	"unicode"
@@ -13,11 +14,18 @@ import (
 	"github.com/gookit/slog"
 )
 
+//nolint:gochecknoglobals // compiled once and only read afterwards
+var (
+	skuPattern          = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+	tagPattern          = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N}_-]{0,31}$`)
+	attributeKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,63}$`)
+)
+
 type Validate struct {
 	*validator.Validate
 }
 
-func NewValidator() *Validate {
+func NewValidator(reasonCodes []string) *Validate {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	if err := validate.RegisterValidation("rfc3339", ValidateRFC3339); err != nil {
@@ -40,6 +48,51 @@ func NewValidator() *Validate {
 		os.Exit(1)
 	}
 
+	if err := validate.RegisterValidation("adjust_reason", ValidateAdjustReason); err != nil {
+		slog.Fatal("Failed to register adjust_reason validation", "error", err)
+		os.Exit(1)
+	}
+
+	if err := validate.RegisterValidation("movement_kind", ValidateMovementKind); err != nil {
+		slog.Fatal("Failed to register movement_kind validation", "error", err)
+		os.Exit(1)
+	}
+
+	if err := validate.RegisterValidation("sku", ValidateSKU); err != nil {
+		slog.Fatal("Failed to register sku validation", "error", err)
+		os.Exit(1)
+	}
+
+	if err := validate.RegisterValidation("ean13", ValidateEAN13); err != nil {
+		slog.Fatal("Failed to register ean13 validation", "error", err)
+		os.Exit(1)
+	}
+
+	if err := validate.RegisterValidation("barcode", ValidateBarcode); err != nil {
+		slog.Fatal("Failed to register barcode validation", "error", err)
+		os.Exit(1)
+	}
+
+	if err := validate.RegisterValidation("webhook_event", ValidateWebhookEvent); err != nil {
+		slog.Fatal("Failed to register webhook_event validation", "error", err)
+		os.Exit(1)
+	}
+
+	if err := validate.RegisterValidation("tag", ValidateTag); err != nil {
+		slog.Fatal("Failed to register tag validation", "error", err)
+		os.Exit(1)
+	}
+
+	if err := validate.RegisterValidation("attribute_key", ValidateAttributeKey); err != nil {
+		slog.Fatal("Failed to register attribute_key validation", "error", err)
+		os.Exit(1)
+	}
+
+	if err := validate.RegisterValidation("reason_code", ReasonCodeValidator(reasonCodes)); err != nil {
+		slog.Fatal("Failed to register reason_code validation", "error", err)
+		os.Exit(1)
+	}
+
 	return &Validate{
 		Validate: validate,
 	}
@@ -103,6 +156,70 @@ func ValidateRole(fl validator.FieldLevel) bool {
 	return false
 }
 
+func ValidateAdjustReason(fl validator.FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return true
+		}
+		field = field.Elem()
+	}
+
+	value := AdjustReason(field.String())
+	if _, ok := AllowedAdjustReasons[value]; ok {
+		return true
+	}
+
+	return false
+}
+
+func ReasonCodeValidator(codes []string) validator.Func {
+	allowed := make(map[string]struct{}, len(codes))
+	for _, code := range codes {
+		allowed[code] = struct{}{}
+	}
+
+	return func(fl validator.FieldLevel) bool {
+		field := fl.Field()
+
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				return true
+			}
+			field = field.Elem()
+		}
+
+		_, ok := allowed[field.String()]
+		return ok
+	}
+}
+
+func ValidateMovementKind(fl validator.FieldLevel) bool {
+	field := fl.Field()
+
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return true
+		}
+		field = field.Elem()
+	}
+
+	value := MovementKind(field.String())
+	if _, ok := AllowedMovementKinds[value]; ok {
+		return true
+	}
+
+	return false
+}
+
+func ValidateWebhookEvent(fl validator.FieldLevel) bool {
+	value := WebhookEvent(fl.Field().String())
+	_, ok := AllowedWebhookEvents[value]
+
+	return ok
+}
+
 func ValidateLettersOnly(fl validator.FieldLevel) bool {
 	value := fl.Field().String()
 	for _, r := range value {
@@ -113,3 +230,80 @@ func ValidateLettersOnly(fl validator.FieldLevel) bool {
 
 	return true
 }
+
+func ValidateSKU(fl validator.FieldLevel) bool {
+	return skuPattern.MatchString(fl.Field().String())
+}
+
+func ValidateTag(fl validator.FieldLevel) bool {
+	return tagPattern.MatchString(fl.Field().String())
+}
+
+func ValidateAttributeKey(fl validator.FieldLevel) bool {
+	return attributeKeyPattern.MatchString(fl.Field().String())
+}
+
+func ValidateEAN13(fl validator.FieldLevel) bool {
+	return IsEAN13(fl.Field().String())
+}
+
+func ValidateBarcode(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if isDigits(value) && len(value) == ean13Length {
+		return IsEAN13(value)
+	}
+
+	return IsCode128(value)
+}
+
+const (
+	ean13Length      = 13
+	code128MaxLength = 48
+)
+
+func IsEAN13(value string) bool {
+	if len(value) != ean13Length || !isDigits(value) {
+		return false
+	}
+
+	sum := 0
+	for i := range ean13Length - 1 {
+		digit := int(value[i] - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	checkDigit := (10 - sum%10) % 10 //nolint:mnd // EAN modulo 10 check digit
+
+	return checkDigit == int(value[ean13Length-1]-'0')
+}
+
+func IsCode128(value string) bool {
+	if value == "" || len(value) > code128MaxLength {
+		return false
+	}
+
+	for i := range len(value) {
+		if value[i] < ' ' || value[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for i := range len(value) {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
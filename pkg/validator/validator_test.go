@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsEAN13(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{name: "valid", value: "4006381333931", want: true},
+		{name: "valid with zero check digit", value: "4600000000008", want: true},
+		{name: "valid from docs", value: "4710562243321", want: true},
+		{name: "all zeros", value: "0000000000000", want: true},
+		{name: "wrong check digit", value: "4006381333932", want: false},
+		{name: "swapped digits", value: "4006381333913", want: false},
+		{name: "too short", value: "400638133393", want: false},
+		{name: "too long", value: "40063813339310", want: false},
+		{name: "letter", value: "400638133393A", want: false},
+		{name: "empty", value: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsEAN13(tt.value); got != tt.want {
+				t.Fatalf("IsEAN13(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCode128(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{name: "alphanumeric", value: "ABC-123/x", want: true},
+		{name: "space and tilde", value: " ~", want: true},
+		{name: "max length", value: strings.Repeat("A", code128MaxLength), want: true},
+		{name: "too long", value: strings.Repeat("A", code128MaxLength+1), want: false},
+		{name: "empty", value: "", want: false},
+		{name: "control character", value: "ABC\t123", want: false},
+		{name: "delete character", value: "ABC\x7f", want: false},
+		{name: "non ascii", value: "Код128", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCode128(tt.value); got != tt.want {
+				t.Fatalf("IsCode128(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBarcode(t *testing.T) {
+	v := NewValidator(nil)
+
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{name: "ean13", value: "5901234123457", want: true},
+		{name: "ean13 with wrong check digit", value: "5901234123458", want: false},
+		{name: "twelve digits as code128", value: "590123412345", want: true},
+		{name: "code128", value: "BOX-0042", want: true},
+		{name: "control character", value: "BOX\n0042", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Var(tt.value, "barcode")
+			if got := err == nil; got != tt.want {
+				t.Fatalf("barcode %q valid = %v, want %v (err: %v)", tt.value, got, tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	const (
+		secret    = "topsecret"
+		timestamp = int64(1766740360)
+	)
+	body := []byte(`{"id":"5b0f3d8e","type":"item.updated"}`)
+
+	signature := Sign(secret, timestamp, body)
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		t.Fatalf("signature %q has no %q prefix", signature, signaturePrefix)
+	}
+
+	if !Verify(secret, timestamp, body, signature) {
+		t.Fatal("Verify rejected a signature produced by Sign")
+	}
+}
+
+func TestVerifyRejectsTampering(t *testing.T) {
+	const (
+		secret    = "topsecret"
+		timestamp = int64(1766740360)
+	)
+	body := []byte(`{"quantity":3}`)
+	signature := Sign(secret, timestamp, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		signature string
+	}{
+		{name: "body", secret: secret, timestamp: timestamp, body: []byte(`{"quantity":4}`), signature: signature},
+		{name: "timestamp", secret: secret, timestamp: timestamp + 1, body: body, signature: signature},
+		{name: "secret", secret: "othersecret", timestamp: timestamp, body: body, signature: signature},
+		{name: "signature", secret: secret, timestamp: timestamp, body: body, signature: flipLastChar(signature)},
+		{name: "prefix", secret: secret, timestamp: timestamp, body: body, signature: strings.TrimPrefix(signature, signaturePrefix)},
+		{name: "empty", secret: secret, timestamp: timestamp, body: body, signature: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Verify(tt.secret, tt.timestamp, tt.body, tt.signature) {
+				t.Fatal("Verify accepted a tampered request")
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	const (
+		base     = 30 * time.Second
+		maxDelay = time.Hour
+	)
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: base},
+		{attempt: 1, want: base},
+		{attempt: 2, want: 2 * base},
+		{attempt: 3, want: 4 * base},
+		{attempt: 7, want: 64 * base},
+		{attempt: 8, want: maxDelay},
+		{attempt: 100, want: maxDelay},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempt, base, maxDelay); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestBackoffBaseAboveMax(t *testing.T) {
+	if got := Backoff(1, time.Hour, time.Minute); got != time.Minute {
+		t.Fatalf("Backoff = %v, want %v", got, time.Minute)
+	}
+}
+
+func flipLastChar(s string) string {
+	last := s[len(s)-1]
+	if last == '0' {
+		return s[:len(s)-1] + "1"
+	}
+
+	return s[:len(s)-1] + "0"
+}